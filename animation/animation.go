@@ -0,0 +1,96 @@
+package animation
+
+import (
+	"time"
+)
+
+// Forever may be used as the Repeat value of a Tween to cause it to repeat
+// until it is stopped.
+const Forever = -1
+
+// Animation defines the methods all animations must implement.
+type Animation interface {
+	// Span returns the total amount of time the animation runs for, including
+	// any delay and repetitions. A negative value indicates the animation runs
+	// until it is stopped.
+	Span() time.Duration
+	// Animate updates the animation to the state it should be in once
+	// 'elapsed' time has passed since it was started. Returns true once the
+	// animation has completed.
+	Animate(elapsed time.Duration) bool
+}
+
+// Tween animates a value from its start to its end over a period of time.
+type Tween struct {
+	// Delay is the amount of time to wait before the first pass begins.
+	Delay time.Duration
+	// Duration is the amount of time a single pass takes.
+	Duration time.Duration
+	// Easing is applied to the progress before it is handed to Update. If nil,
+	// Linear is used.
+	Easing Easing
+	// Repeat is the number of additional passes to make after the first one.
+	// Use Forever to repeat until stopped.
+	Repeat int
+	// Reverse causes every other pass to run backwards, from end to start.
+	Reverse bool
+	// Update is called with the eased progress, typically in the range 0-1,
+	// each time the animation advances.
+	Update func(progress float64)
+	// Done, if not nil, is called once the final pass completes.
+	Done     func()
+	notified bool
+}
+
+// NewTween creates a new Tween that calls 'update' with the eased progress of
+// the animation over the specified duration.
+func NewTween(duration time.Duration, easing Easing, update func(progress float64)) *Tween {
+	return &Tween{Duration: duration, Easing: easing, Update: update}
+}
+
+// Span implements the Animation interface.
+func (t *Tween) Span() time.Duration {
+	if t.Repeat < 0 {
+		return -1
+	}
+	return t.Delay + t.Duration*time.Duration(t.Repeat+1)
+}
+
+// Animate implements the Animation interface.
+func (t *Tween) Animate(elapsed time.Duration) bool {
+	elapsed -= t.Delay
+	if elapsed < 0 {
+		t.notified = false
+		return false
+	}
+	pass := 0
+	fraction := 1.0
+	if t.Duration > 0 {
+		pass = int(elapsed / t.Duration)
+		fraction = float64(elapsed%t.Duration) / float64(t.Duration)
+	}
+	done := t.Repeat >= 0 && (t.Duration <= 0 || pass > t.Repeat)
+	if done {
+		pass = t.Repeat
+		fraction = 1
+	} else {
+		t.notified = false
+	}
+	if t.Reverse && pass%2 == 1 {
+		fraction = 1 - fraction
+	}
+	if t.Update != nil {
+		easing := t.Easing
+		if easing == nil {
+			easing = Linear
+		}
+		t.Update(easing(fraction))
+	}
+	if done && !t.notified {
+		t.notified = true
+		if t.Done != nil {
+			t.Done()
+		}
+	}
+	return done
+}
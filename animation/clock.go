@@ -0,0 +1,148 @@
+package animation
+
+import (
+	"time"
+
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+)
+
+var (
+	// FrameInterval holds the amount of time between animation frames.
+	FrameInterval = time.Second / 60
+	clocks        = make(map[uint64]*Clock)
+)
+
+// Clock drives the animations for a single window. All of the animations
+// running within the window are advanced together from a single timer, so the
+// repaints they cause are coalesced into one update per frame.
+type Clock struct {
+	window  ui.Window
+	running []*Handle
+	pending bool
+}
+
+// Handle provides control over a running animation.
+type Handle struct {
+	clock     *Clock
+	animation Animation
+	started   time.Time
+	done      bool
+}
+
+// ClockFor returns the Clock for the window, creating it if necessary.
+func ClockFor(wnd ui.Window) *Clock {
+	id := wnd.ID()
+	clock, ok := clocks[id]
+	if !ok {
+		clock = &Clock{window: wnd}
+		clocks[id] = clock
+		wnd.EventHandlers().Add(event.ClosedType, func(evt event.Event) {
+			clock.StopAll()
+			delete(clocks, id)
+		})
+	}
+	return clock
+}
+
+// Start the animation using the Clock of the window. Must be called on the UI
+// thread.
+func Start(wnd ui.Window, animation Animation) *Handle {
+	return ClockFor(wnd).Start(animation)
+}
+
+// Start the animation. The first frame is produced immediately. Must be called
+// on the UI thread.
+func (clock *Clock) Start(animation Animation) *Handle {
+	handle := &Handle{clock: clock, animation: animation, started: time.Now()}
+	if animation.Animate(0) {
+		handle.done = true
+	} else {
+		clock.running = append(clock.running, handle)
+		clock.schedule()
+	}
+	return handle
+}
+
+// Running returns the number of animations the Clock is currently driving.
+func (clock *Clock) Running() int {
+	return len(clock.running)
+}
+
+// StopAll stops all animations the Clock is currently driving, leaving them in
+// whatever state they are currently in.
+func (clock *Clock) StopAll() {
+	for _, handle := range clock.running {
+		handle.done = true
+	}
+	clock.running = nil
+}
+
+func (clock *Clock) schedule() {
+	if !clock.pending && len(clock.running) > 0 && clock.window.Valid() {
+		clock.pending = true
+		clock.window.InvokeAfter(clock.tick, FrameInterval)
+	}
+}
+
+func (clock *Clock) tick() {
+	clock.pending = false
+	now := time.Now()
+	current := clock.running
+	clock.running = nil
+	running := make([]*Handle, 0, len(current))
+	for _, handle := range current {
+		if !handle.done {
+			if handle.animation.Animate(now.Sub(handle.started)) {
+				handle.done = true
+			} else {
+				running = append(running, handle)
+			}
+		}
+	}
+	// Animations started from within a callback during this frame have been
+	// collected in clock.running and will get their next frame along with the
+	// rest.
+	started := clock.running
+	clock.running = running[:0]
+	for _, handle := range running {
+		if !handle.done {
+			clock.running = append(clock.running, handle)
+		}
+	}
+	clock.running = append(clock.running, started...)
+	clock.schedule()
+}
+
+// Running returns true if the animation has not yet completed or been
+// stopped.
+func (handle *Handle) Running() bool {
+	return !handle.done
+}
+
+// Stop the animation, leaving it in whatever state it is currently in.
+func (handle *Handle) Stop() {
+	if !handle.done {
+		handle.done = true
+		for i, one := range handle.clock.running {
+			if one == handle {
+				copy(handle.clock.running[i:], handle.clock.running[i+1:])
+				count := len(handle.clock.running) - 1
+				handle.clock.running[count] = nil
+				handle.clock.running = handle.clock.running[:count]
+				break
+			}
+		}
+	}
+}
+
+// Finish the animation, moving it to its final state. Has no effect on
+// animations that run until stopped.
+func (handle *Handle) Finish() {
+	if !handle.done {
+		if span := handle.animation.Span(); span >= 0 {
+			handle.animation.Animate(span)
+		}
+		handle.Stop()
+	}
+}
@@ -0,0 +1,127 @@
+package animation
+
+import (
+	"math"
+)
+
+// Easing maps the linear progress of an animation, in the range 0-1, to the
+// progress that should be presented. Most easing functions return values in
+// the range 0-1, but some may overshoot on either side.
+type Easing func(t float64) float64
+
+// Predefined easing curves.
+var (
+	// Linear progresses at a constant rate.
+	Linear Easing = func(t float64) float64 { return t }
+	// EaseIn starts slowly and accelerates, matching the CSS "ease-in" curve.
+	EaseIn = Bezier(0.42, 0, 1, 1)
+	// EaseOut starts quickly and decelerates, matching the CSS "ease-out" curve.
+	EaseOut = Bezier(0, 0, 0.58, 1)
+	// EaseInOut starts and ends slowly, matching the CSS "ease-in-out" curve.
+	EaseInOut = Bezier(0.42, 0, 0.58, 1)
+	// QuadIn accelerates using a quadratic curve.
+	QuadIn Easing = func(t float64) float64 { return t * t }
+	// QuadOut decelerates using a quadratic curve.
+	QuadOut Easing = func(t float64) float64 { return t * (2 - t) }
+	// QuadInOut accelerates then decelerates using a quadratic curve.
+	QuadInOut = InOut(QuadIn)
+	// CubicIn accelerates using a cubic curve.
+	CubicIn Easing = func(t float64) float64 { return t * t * t }
+	// CubicOut decelerates using a cubic curve.
+	CubicOut = Out(CubicIn)
+	// CubicInOut accelerates then decelerates using a cubic curve.
+	CubicInOut = InOut(CubicIn)
+	// SineIn accelerates using a sinusoidal curve.
+	SineIn Easing = func(t float64) float64 { return 1 - math.Cos(t*math.Pi/2) }
+	// SineOut decelerates using a sinusoidal curve.
+	SineOut Easing = func(t float64) float64 { return math.Sin(t * math.Pi / 2) }
+	// SineInOut accelerates then decelerates using a sinusoidal curve.
+	SineInOut Easing = func(t float64) float64 { return (1 - math.Cos(t*math.Pi)) / 2 }
+	// ExpoOut decelerates sharply, which works well for momentum effects.
+	ExpoOut Easing = func(t float64) float64 {
+		if t >= 1 {
+			return 1
+		}
+		return 1 - math.Pow(2, -10*t)
+	}
+	// BackOut overshoots the target slightly before settling on it.
+	BackOut Easing = func(t float64) float64 {
+		const s = 1.70158
+		t--
+		return t*t*((s+1)*t+s) + 1
+	}
+)
+
+// Out returns an easing that is the mirror image of 'in', turning an
+// accelerating curve into a decelerating one.
+func Out(in Easing) Easing {
+	return func(t float64) float64 { return 1 - in(1-t) }
+}
+
+// InOut returns an easing that uses 'in' for the first half of the animation
+// and its mirror image for the second half.
+func InOut(in Easing) Easing {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return in(t*2) / 2
+		}
+		return 1 - in((1-t)*2)/2
+	}
+}
+
+// Bezier returns an easing defined by a cubic Bezier curve running from (0,0)
+// to (1,1) with the control points (x1,y1) and (x2,y2), in the same manner as
+// the CSS cubic-bezier() timing function. 'x1' and 'x2' should be in the range
+// 0-1.
+func Bezier(x1, y1, x2, y2 float64) Easing {
+	cx := 3 * x1
+	bx := 3*(x2-x1) - cx
+	ax := 1 - cx - bx
+	cy := 3 * y1
+	by := 3*(y2-y1) - cy
+	ay := 1 - cy - by
+	sampleX := func(t float64) float64 { return ((ax*t+bx)*t + cx) * t }
+	sampleY := func(t float64) float64 { return ((ay*t+by)*t + cy) * t }
+	slopeX := func(t float64) float64 { return (3*ax*t+2*bx)*t + cx }
+	return func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		if x >= 1 {
+			return 1
+		}
+		// Newton-Raphson first, as it converges quickly for most curves
+		t := x
+		for i := 0; i < 8; i++ {
+			dx := sampleX(t) - x
+			if math.Abs(dx) < 1e-6 {
+				return sampleY(t)
+			}
+			d := slopeX(t)
+			if math.Abs(d) < 1e-6 {
+				break
+			}
+			t -= dx / d
+		}
+		// Fall back to bisection
+		lo := 0.0
+		hi := 1.0
+		t = x
+		for lo < hi {
+			v := sampleX(t)
+			if math.Abs(v-x) < 1e-6 {
+				break
+			}
+			if x > v {
+				lo = t
+			} else {
+				hi = t
+			}
+			t = (hi-lo)/2 + lo
+			if hi-lo < 1e-9 {
+				break
+			}
+		}
+		return sampleY(t)
+	}
+}
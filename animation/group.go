@@ -0,0 +1,77 @@
+package animation
+
+import (
+	"time"
+)
+
+// Group is an animation made up of other animations, which are run either one
+// after the other or all at the same time.
+type Group struct {
+	animations []Animation
+	parallel   bool
+	current    int
+	offset     time.Duration
+	last       time.Duration
+}
+
+// Sequence creates a new Group that runs each of the animations in turn,
+// starting the next one once the previous one completes.
+func Sequence(animations ...Animation) *Group {
+	return &Group{animations: animations}
+}
+
+// Parallel creates a new Group that runs all of the animations at the same
+// time. The group completes once all of its animations have completed.
+func Parallel(animations ...Animation) *Group {
+	return &Group{animations: animations, parallel: true}
+}
+
+// Span implements the Animation interface.
+func (g *Group) Span() time.Duration {
+	var span time.Duration
+	for _, one := range g.animations {
+		s := one.Span()
+		if s < 0 {
+			return -1
+		}
+		if g.parallel {
+			if span < s {
+				span = s
+			}
+		} else {
+			span += s
+		}
+	}
+	return span
+}
+
+// Animate implements the Animation interface.
+func (g *Group) Animate(elapsed time.Duration) bool {
+	if g.parallel {
+		done := true
+		for _, one := range g.animations {
+			if !one.Animate(elapsed) {
+				done = false
+			}
+		}
+		return done
+	}
+	if elapsed < g.last {
+		g.current = 0
+		g.offset = 0
+	}
+	g.last = elapsed
+	for g.current < len(g.animations) {
+		one := g.animations[g.current]
+		span := one.Span()
+		if span < 0 || elapsed < g.offset+span {
+			one.Animate(elapsed - g.offset)
+			return false
+		}
+		// Give the finished animation a chance to reach its final state
+		one.Animate(span)
+		g.offset += span
+		g.current++
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package animation
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+)
+
+// NewFloat creates a new Tween that animates a float64 value from 'from' to
+// 'to', calling 'update' with each intermediate value.
+func NewFloat(from, to float64, duration time.Duration, update func(value float64)) *Tween {
+	return NewTween(duration, EaseInOut, func(progress float64) {
+		update(lerp(from, to, progress))
+	})
+}
+
+// NewPoint creates a new Tween that animates a point from 'from' to 'to',
+// calling 'update' with each intermediate value.
+func NewPoint(from, to geom.Point, duration time.Duration, update func(value geom.Point)) *Tween {
+	return NewTween(duration, EaseInOut, func(progress float64) {
+		update(geom.Point{X: lerp(from.X, to.X, progress), Y: lerp(from.Y, to.Y, progress)})
+	})
+}
+
+// NewRect creates a new Tween that animates a rectangle from 'from' to 'to',
+// calling 'update' with each intermediate value.
+func NewRect(from, to geom.Rect, duration time.Duration, update func(value geom.Rect)) *Tween {
+	return NewTween(duration, EaseInOut, func(progress float64) {
+		update(geom.Rect{
+			Point: geom.Point{X: lerp(from.X, to.X, progress), Y: lerp(from.Y, to.Y, progress)},
+			Size:  geom.Size{Width: lerp(from.Width, to.Width, progress), Height: lerp(from.Height, to.Height, progress)},
+		})
+	})
+}
+
+// NewColor creates a new Tween that animates a color, including its alpha
+// channel, from 'from' to 'to', calling 'update' with each intermediate value.
+func NewColor(from, to color.Color, duration time.Duration, update func(value color.Color)) *Tween {
+	return NewTween(duration, EaseInOut, func(progress float64) {
+		update(from.Blend(to, progress).SetAlphaIntensity(lerp(from.AlphaIntensity(), to.AlphaIntensity(), progress)))
+	})
+}
+
+func lerp(from, to, progress float64) float64 {
+	return from + (to-from)*progress
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/object"
@@ -41,10 +42,15 @@ func (a *app) ParentTarget() event.Target {
 // Start the user interface. Locks the calling goroutine to its current OS
 // thread. Does not return.
 func Start() {
-	runtime.LockOSThread()
+	startUIThread()
 	platformAppStart()
 }
 
+func startUIThread() {
+	runtime.LockOSThread()
+	atomic.StoreUint64(&uiGoroutine, goroutineID())
+}
+
 // Name returns the application's name.
 func Name() string {
 	return platformAppName()
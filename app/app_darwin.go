@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package app
 
 import (
@@ -7,6 +10,7 @@ import (
 	"C"
 
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/internal/task"
 	"github.com/richardwilkes/ui/menu/macmenus"
 )
 
@@ -87,3 +91,12 @@ func cbAppWillResignActive() {
 func cbAppDidResignActive() {
 	event.SendAppDidDeactivate()
 }
+
+func platformInvoke(id uint64) {
+	C.appInvoke(C.ulong(id))
+}
+
+//export appDispatchTask
+func appDispatchTask(id uint64) {
+	task.Dispatch(id)
+}
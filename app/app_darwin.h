@@ -7,3 +7,4 @@ void hideOtherApps();
 void showAllApps();
 void attemptQuit();
 void appMayQuitNow(int quit);
+void appInvoke(unsigned long id);
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 #include "app_darwin.h"
 #include "_cgo_export.h"
 
@@ -80,3 +83,7 @@ void attemptQuit() {
 void appMayQuitNow(int quit) {
 	[NSApp replyToApplicationShouldTerminate:quit];
 }
+
+void appInvoke(unsigned long id) {
+	dispatch_async_f(dispatch_get_main_queue(), (void *)id, (dispatch_function_t)appDispatchTask);
+}
@@ -0,0 +1,88 @@
+//go:build headless
+// +build headless
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/internal/headless"
+	"github.com/richardwilkes/ui/menu/custom"
+	"github.com/richardwilkes/ui/window"
+)
+
+func platformAppStart() {
+	window.LastWindowClosed = func() {
+		if ShouldQuitAfterLastWindowClosed() {
+			AttemptQuit()
+		}
+	}
+	custom.Install()
+	event.SendAppWillFinishStartup()
+	event.SendAppDidFinishStartup()
+	if window.Count() == 0 && ShouldQuitAfterLastWindowClosed() {
+		AttemptQuit()
+	}
+	window.RunEventLoop()
+}
+
+// StartHeadless prepares the user interface for use without a display, but unlike Start, returns
+// immediately rather than running the event loop. The caller is then responsible for calling
+// window.PumpEvents from the same goroutine to process tasks and injected events. Locks the
+// calling goroutine to its current OS thread.
+func StartHeadless() {
+	startUIThread()
+	window.LastWindowClosed = func() {
+		if ShouldQuitAfterLastWindowClosed() {
+			AttemptQuit()
+		}
+	}
+	custom.Install()
+	event.SendAppWillFinishStartup()
+	event.SendAppDidFinishStartup()
+}
+
+// BindUIThread makes the calling goroutine the UI thread, in place of the one that called
+// StartHeadless, and locks it to its current OS thread. This allows a different goroutine, such as
+// the one running the next test, to take over driving the user interface.
+func BindUIThread() {
+	startUIThread()
+}
+
+func platformAppName() string {
+	return filepath.Base(os.Args[0])
+}
+
+func platformHideApp() {
+	for _, wnd := range window.Windows() {
+		wnd.Minimize()
+	}
+}
+
+func platformHideOtherApps() {
+	// Not supported
+}
+
+func platformShowAllApps() {
+	// Not supported
+}
+
+func platformAttemptQuit() {
+	switch ShouldQuit() {
+	case Cancel:
+	case Later:
+		window.DeferQuit()
+	default:
+		window.StartQuit()
+	}
+}
+
+func platformMayQuitNow(quit bool) {
+	window.ResumeQuit(quit)
+}
+
+func platformInvoke(id uint64) {
+	headless.Post(id)
+}
@@ -1,17 +1,37 @@
+//go:build !headless
+// +build !headless
+
 package app
 
 import (
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/font"
 	"github.com/richardwilkes/ui/internal/x11"
 	"github.com/richardwilkes/ui/menu/custom"
 	"github.com/richardwilkes/ui/window"
 )
 
+var (
+	pendingLock  sync.Mutex
+	pendingTasks []uint64
+	displayReady bool
+)
+
 func platformAppStart() {
+	pendingLock.Lock()
 	x11.OpenDisplay()
+	font.ApplyDesktopSettings(x11.DesktopFontSettings())
+	displayReady = true
+	pending := pendingTasks
+	pendingTasks = nil
+	pendingLock.Unlock()
+	for _, id := range pending {
+		x11.InvokeTask(id)
+	}
 	window.LastWindowClosed = func() {
 		if ShouldQuitAfterLastWindowClosed() {
 			AttemptQuit()
@@ -57,3 +77,15 @@ func platformAttemptQuit() {
 func platformMayQuitNow(quit bool) {
 	window.ResumeQuit(quit)
 }
+
+func platformInvoke(id uint64) {
+	pendingLock.Lock()
+	if !displayReady {
+		// The display hasn't been opened yet, so hold on to the task until it is
+		pendingTasks = append(pendingTasks, id)
+		pendingLock.Unlock()
+		return
+	}
+	pendingLock.Unlock()
+	x11.InvokeTask(id)
+}
@@ -1,9 +1,13 @@
+//go:build !headless
+// +build !headless
+
 package app
 
 import (
 	"os"
 	"path/filepath"
 
+	"github.com/richardwilkes/ui/internal/task"
 	"github.com/richardwilkes/ui/menu/custom"
 	"github.com/richardwilkes/ui/window"
 )
@@ -38,3 +42,9 @@ func platformAttemptQuit() {
 func platformMayQuitNow(quit bool) {
 	panic("unimplemented")
 }
+
+func platformInvoke(id uint64) {
+	// RAW: Implement for Windows. Until there is an event loop to post the task to, run it right
+	// away, so that callers waiting on it don't block forever.
+	task.Dispatch(id)
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/richardwilkes/ui/internal/task"
+)
+
+// uiGoroutine holds the ID of the goroutine acting as the UI thread. It is rebound by tests while
+// other goroutines may be reading it, so it must only be accessed atomically.
+var uiGoroutine uint64
+
+// Timer represents a task scheduled with InvokeAfter.
+type Timer struct {
+	lock     sync.Mutex
+	timer    *time.Timer
+	id       uint64
+	started  bool
+	canceled bool
+}
+
+// Invoke schedules a task to be run on the UI thread. May be called from any goroutine.
+func Invoke(taskFunction func()) {
+	platformInvoke(task.Record(taskFunction))
+}
+
+// InvokeAfter schedules a task to be run on the UI thread after waiting for the specified duration.
+// May be called from any goroutine. The returned Timer may be used to cancel the task.
+func InvokeAfter(taskFunction func(), after time.Duration) *Timer {
+	t := &Timer{}
+	t.id = task.Record(func() {
+		t.lock.Lock()
+		run := !t.canceled
+		t.started = run
+		t.lock.Unlock()
+		if run {
+			taskFunction()
+		}
+	})
+	t.timer = time.AfterFunc(after, func() {
+		platformInvoke(t.id)
+	})
+	return t
+}
+
+// InvokeAndWait runs a task on the UI thread, waiting for it to complete before returning. When
+// called from the UI thread itself, waiting would deadlock, so the task is run immediately instead.
+func InvokeAndWait(taskFunction func()) {
+	if OnUIThread() {
+		taskFunction()
+		return
+	}
+	done := make(chan struct{})
+	Invoke(func() {
+		defer close(done)
+		taskFunction()
+	})
+	<-done
+}
+
+// OnUIThread returns true if the caller is running on the UI thread.
+func OnUIThread() bool {
+	id := atomic.LoadUint64(&uiGoroutine)
+	return id != 0 && id == goroutineID()
+}
+
+// Cancel prevents the task from running. Returns true if the task had not yet started and will now
+// never run.
+func (t *Timer) Cancel() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.started || t.canceled {
+		return false
+	}
+	t.canceled = true
+	t.timer.Stop()
+	task.Cancel(t.id)
+	return true
+}
+
+func goroutineID() uint64 {
+	var buffer [64]byte
+	// The stack trace always starts with "goroutine <id> ["
+	fields := bytes.Fields(buffer[:runtime.Stack(buffer[:], false)])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
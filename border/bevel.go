@@ -0,0 +1,94 @@
+package border
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Bevel is a border that makes its contents appear raised above or lowered into the surrounding
+// area by drawing lighter and darker edges.
+type Bevel struct {
+	highlight color.Color
+	shadow    color.Color
+	thickness float64
+	raised    bool
+}
+
+// NewBevel creates a new bevel border. When 'raised' is true, the top and left edges are drawn
+// with the highlight color and the bottom and right edges with the shadow color. When false,
+// the colors are swapped.
+func NewBevel(raised bool, highlight, shadow color.Color, thickness float64) *Bevel {
+	return &Bevel{highlight: highlight, shadow: shadow, thickness: thickness, raised: raised}
+}
+
+// Insets implements the Border interface.
+func (b *Bevel) Insets() geom.Insets {
+	return geom.NewUniformInsets(b.thickness)
+}
+
+// Draw implements the Border interface.
+func (b *Bevel) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	topLeft := b.highlight
+	bottomRight := b.shadow
+	if !b.raised {
+		topLeft, bottomRight = bottomRight, topLeft
+	}
+	drawBevel(gc, bounds, b.thickness, topLeft, bottomRight)
+}
+
+func drawBevel(gc *draw.Graphics, bounds geom.Rect, thickness float64, topLeft, bottomRight color.Color) {
+	right := bounds.X + bounds.Width
+	bottom := bounds.Y + bounds.Height
+	gc.Save()
+	gc.BeginPath()
+	gc.MoveTo(bounds.X, bounds.Y)
+	gc.LineTo(right, bounds.Y)
+	gc.LineTo(right-thickness, bounds.Y+thickness)
+	gc.LineTo(bounds.X+thickness, bounds.Y+thickness)
+	gc.LineTo(bounds.X+thickness, bottom-thickness)
+	gc.LineTo(bounds.X, bottom)
+	gc.ClosePath()
+	gc.SetColor(topLeft)
+	gc.FillPath()
+	gc.MoveTo(right, bottom)
+	gc.LineTo(bounds.X, bottom)
+	gc.LineTo(bounds.X+thickness, bottom-thickness)
+	gc.LineTo(right-thickness, bottom-thickness)
+	gc.LineTo(right-thickness, bounds.Y+thickness)
+	gc.LineTo(right, bounds.Y)
+	gc.ClosePath()
+	gc.SetColor(bottomRight)
+	gc.FillPath()
+	gc.Restore()
+}
+
+// Etched is a border that draws a groove or ridge around its edges.
+type Etched struct {
+	highlight color.Color
+	shadow    color.Color
+	raised    bool
+}
+
+// NewEtched creates a new etched border. When 'raised' is true, a ridge is drawn. When false, a
+// groove is drawn.
+func NewEtched(raised bool, highlight, shadow color.Color) *Etched {
+	return &Etched{highlight: highlight, shadow: shadow, raised: raised}
+}
+
+// Insets implements the Border interface.
+func (e *Etched) Insets() geom.Insets {
+	return geom.NewUniformInsets(2)
+}
+
+// Draw implements the Border interface.
+func (e *Etched) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	outer := e.shadow
+	inner := e.highlight
+	if e.raised {
+		outer, inner = inner, outer
+	}
+	drawBevel(gc, bounds, 1, outer, inner)
+	bounds.InsetUniform(1)
+	drawBevel(gc, bounds, 1, inner, outer)
+}
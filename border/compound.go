@@ -34,3 +34,16 @@ func (c *Compound) Draw(gc *draw.Graphics, bounds geom.Rect) {
 		bounds.Inset(one.Insets())
 	}
 }
+
+// Shape implements the Shaped interface. The shape of the outermost border is used, if it
+// provides one.
+func (c *Compound) Shape(bounds geom.Rect) *draw.Path {
+	if len(c.borders) > 0 {
+		if shaped, ok := c.borders[0].(Shaped); ok {
+			return shaped.Shape(bounds)
+		}
+	}
+	path := draw.NewPath()
+	path.Rect(bounds)
+	return path
+}
@@ -0,0 +1,45 @@
+package border
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Dashed is a border that draws a dashed or dotted line around its edges.
+type Dashed struct {
+	color     color.Color
+	thickness float64
+	segments  []float64
+	cap       draw.LineCap
+}
+
+// NewDashed creates a new dashed border. 'segments' alternately describes the lengths of the
+// drawn and skipped portions of the line, as for Graphics.SetDash().
+func NewDashed(color color.Color, thickness float64, segments ...float64) *Dashed {
+	return &Dashed{color: color, thickness: thickness, segments: segments, cap: draw.LineCapButt}
+}
+
+// NewDotted creates a new border drawn as a series of round dots.
+func NewDotted(color color.Color, thickness float64) *Dashed {
+	return &Dashed{color: color, thickness: thickness, segments: []float64{0, thickness * 2}, cap: draw.LineCapRound}
+}
+
+// Insets implements the Border interface.
+func (d *Dashed) Insets() geom.Insets {
+	return geom.NewUniformInsets(d.thickness)
+}
+
+// Draw implements the Border interface.
+func (d *Dashed) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	bounds.InsetUniform(d.thickness / 2)
+	gc.Save()
+	gc.SetColor(d.color)
+	gc.SetStrokeWidth(d.thickness)
+	gc.SetLineCap(d.cap)
+	gc.SetDash(d.segments, 0)
+	gc.BeginPath()
+	gc.Rect(bounds)
+	gc.StrokePath()
+	gc.Restore()
+}
@@ -0,0 +1,33 @@
+package border
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Image is a border that draws a nine-slice image around its edges.
+type Image struct {
+	slice *draw.NineSlice
+	// DrawCenter will cause the centre of the nine-slice image to be drawn as well if true.
+	DrawCenter bool
+}
+
+// NewImage creates a new image border. The nine-slice image's insets determine how thick the
+// border is on each edge.
+func NewImage(slice *draw.NineSlice) *Image {
+	return &Image{slice: slice}
+}
+
+// Insets implements the Border interface.
+func (img *Image) Insets() geom.Insets {
+	return img.slice.Insets()
+}
+
+// Draw implements the Border interface.
+func (img *Image) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	if img.DrawCenter {
+		img.slice.Draw(gc, bounds)
+	} else {
+		img.slice.DrawEdges(gc, bounds)
+	}
+}
@@ -0,0 +1,46 @@
+package border
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Rounded is a border that draws a line of uniform thickness around a rectangle with rounded
+// corners.
+type Rounded struct {
+	color     color.Color
+	thickness float64
+	radii     CornerRadii
+}
+
+// NewRounded creates a new rounded border. 'radii' describes the outer radius of each corner.
+func NewRounded(color color.Color, thickness float64, radii CornerRadii) *Rounded {
+	return &Rounded{color: color, thickness: thickness, radii: radii}
+}
+
+// Insets implements the Border interface.
+func (r *Rounded) Insets() geom.Insets {
+	return geom.NewUniformInsets(r.thickness)
+}
+
+// Shape implements the Shaped interface.
+func (r *Rounded) Shape(bounds geom.Rect) *draw.Path {
+	return RoundedRectPath(bounds, r.radii)
+}
+
+// Draw implements the Border interface.
+func (r *Rounded) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	inner := bounds
+	inner.InsetUniform(r.thickness)
+	gc.Save()
+	gc.BeginPath()
+	gc.AddPath(RoundedRectPath(bounds, r.radii))
+	if !inner.IsEmpty() {
+		gc.AddPath(RoundedRectPath(inner, r.radii.Inset(r.thickness)))
+	}
+	gc.SetFillRule(draw.FillRuleEvenOdd)
+	gc.SetColor(r.color)
+	gc.FillPath()
+	gc.Restore()
+}
@@ -0,0 +1,75 @@
+package border
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Shaped should be implemented by borders whose outer edge is not a simple rectangle. Widgets
+// clip their background and content to the shape of such borders.
+type Shaped interface {
+	// Shape returns the outline of the border when drawn into 'bounds'.
+	Shape(bounds geom.Rect) *draw.Path
+}
+
+// CornerRadii holds the radius of each corner of a rounded rectangle.
+type CornerRadii struct {
+	TopLeft     float64
+	TopRight    float64
+	BottomRight float64
+	BottomLeft  float64
+}
+
+// NewUniformCornerRadii creates a new CornerRadii with the same radius for each corner.
+func NewUniformCornerRadii(radius float64) CornerRadii {
+	return CornerRadii{TopLeft: radius, TopRight: radius, BottomRight: radius, BottomLeft: radius}
+}
+
+// Inset returns a copy of the radii reduced by 'amount', stopping at zero.
+func (radii CornerRadii) Inset(amount float64) CornerRadii {
+	return CornerRadii{TopLeft: math.Max(radii.TopLeft-amount, 0), TopRight: math.Max(radii.TopRight-amount, 0), BottomRight: math.Max(radii.BottomRight-amount, 0), BottomLeft: math.Max(radii.BottomLeft-amount, 0)}
+}
+
+// RoundedRectPath creates a path describing a rectangle with rounded corners. The radii are
+// reduced as necessary so that adjacent corners don't overlap.
+func RoundedRectPath(bounds geom.Rect, radii CornerRadii) *draw.Path {
+	factor := 1.0
+	for _, one := range []struct{ a, b, length float64 }{
+		{radii.TopLeft, radii.TopRight, bounds.Width},
+		{radii.BottomLeft, radii.BottomRight, bounds.Width},
+		{radii.TopLeft, radii.BottomLeft, bounds.Height},
+		{radii.TopRight, radii.BottomRight, bounds.Height},
+	} {
+		if sum := one.a + one.b; sum > one.length && sum > 0 {
+			factor = math.Min(factor, one.length/sum)
+		}
+	}
+	tl := radii.TopLeft * factor
+	tr := radii.TopRight * factor
+	br := radii.BottomRight * factor
+	bl := radii.BottomLeft * factor
+	right := bounds.X + bounds.Width
+	bottom := bounds.Y + bounds.Height
+	path := draw.NewPath()
+	path.MoveTo(bounds.X+tl, bounds.Y)
+	path.LineTo(right-tr, bounds.Y)
+	if tr > 0 {
+		path.Arc(right-tr, bounds.Y+tr, tr, -math.Pi/2, 0, true)
+	}
+	path.LineTo(right, bottom-br)
+	if br > 0 {
+		path.Arc(right-br, bottom-br, br, 0, math.Pi/2, true)
+	}
+	path.LineTo(bounds.X+bl, bottom)
+	if bl > 0 {
+		path.Arc(bounds.X+bl, bottom-bl, bl, math.Pi/2, math.Pi, true)
+	}
+	path.LineTo(bounds.X, bounds.Y+tl)
+	if tl > 0 {
+		path.Arc(bounds.X+tl, bounds.Y+tl, tl, math.Pi, 3*math.Pi/2, true)
+	}
+	path.ClosePath()
+	return path
+}
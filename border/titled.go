@@ -0,0 +1,75 @@
+package border
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/font"
+)
+
+const (
+	titleIndent  = 8
+	titleGap     = 4
+	titlePadding = 4
+)
+
+// Titled is a border that draws a line around its edges with a caption set into the top edge,
+// typically used to group related controls.
+type Titled struct {
+	title     string
+	font      *font.Font
+	lineColor color.Color
+	textColor color.Color
+}
+
+// NewTitled creates a new titled border.
+func NewTitled(title string, f *font.Font, lineColor, textColor color.Color) *Titled {
+	return &Titled{title: title, font: f, lineColor: lineColor, textColor: textColor}
+}
+
+// Title returns the caption.
+func (t *Titled) Title() string {
+	return t.title
+}
+
+// Insets implements the Border interface.
+func (t *Titled) Insets() geom.Insets {
+	insets := geom.NewUniformInsets(1 + titlePadding)
+	insets.Top = math.Max(t.font.Height(), 1) + titlePadding
+	return insets
+}
+
+// Draw implements the Border interface.
+func (t *Titled) Draw(gc *draw.Graphics, bounds geom.Rect) {
+	height := t.font.Height()
+	frame := bounds
+	frame.Y += math.Floor(height / 2)
+	frame.Height -= frame.Y - bounds.Y
+	// Keep the 1 pixel line within the bounds by centering it on the inner half of the edge pixels
+	frame.InsetUniform(0.5)
+	gc.Save()
+	gc.SetStrokeWidth(1)
+	if t.title != "" {
+		size := t.font.Measure(t.title)
+		caption := geom.Rect{Point: geom.Point{X: bounds.X + titleIndent, Y: bounds.Y}, Size: geom.Size{Width: math.Min(size.Width+2*titleGap, math.Max(bounds.Width-2*titleIndent, 0)), Height: height}}
+		gc.BeginPath()
+		gc.Rect(bounds)
+		gc.Rect(caption)
+		gc.SetFillRule(draw.FillRuleEvenOdd)
+		gc.Clip()
+		gc.SetColor(t.lineColor)
+		gc.StrokeRect(frame)
+		gc.Restore()
+		gc.Save()
+		gc.Rect(caption)
+		gc.Clip()
+		gc.SetColor(t.textColor)
+		gc.DrawString(caption.X+titleGap, caption.Y, t.title, t.font)
+	} else {
+		gc.SetColor(t.lineColor)
+		gc.StrokeRect(frame)
+	}
+	gc.Restore()
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 #include "clip_darwin.h"
 
 int clipboardChangeCount() {
@@ -0,0 +1,44 @@
+//go:build headless
+// +build headless
+
+package clipboard
+
+import (
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+)
+
+var (
+	headlessChangeCount int
+	headlessData        []datatypes.Data
+)
+
+func platformChangeCount() int {
+	return headlessChangeCount
+}
+
+func platformClear() {
+	headlessData = nil
+	headlessChangeCount++
+}
+
+func platformTypes() []string {
+	types := make([]string, len(headlessData))
+	for i, one := range headlessData {
+		types[i] = one.MimeType
+	}
+	return types
+}
+
+func platformGetData(dataType string) []byte {
+	for _, one := range headlessData {
+		if one.MimeType == dataType {
+			return one.Bytes
+		}
+	}
+	return []byte{}
+}
+
+func platformSetData(data []datatypes.Data) {
+	headlessData = data
+	headlessChangeCount++
+}
@@ -13,4 +13,8 @@ var (
 	TextBackground = White
 	// Text is the system color used for the text in editable text areas.
 	Text = Black
+	// FindHighlight is the system color used for the background of text matching a search.
+	FindHighlight = RGB(255, 228, 92)
+	// SpellingError is the system color used to underline misspelled words.
+	SpellingError = Red
 )
@@ -1,6 +1,7 @@
 package cursor
 
 import (
+	"math"
 	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
@@ -57,7 +58,8 @@ type Cursor struct {
 	cursor unsafe.Pointer
 }
 
-// NewCursor creates a new cursor from an image.
+// NewCursor creates a new cursor from an image. The image and hot spot are in logical units and are
+// scaled to match the display where the platform requires it.
 func NewCursor(imgData *draw.ImageData, hotSpot geom.Point) *Cursor {
 	return &Cursor{id: custom, cursor: platformNewCursor(imgData, hotSpot)}
 }
@@ -77,3 +79,18 @@ func (cursor *Cursor) Dispose() {
 		cursor.cursor = nil
 	}
 }
+
+// scaleCursorImage resamples the cursor image and moves its hot spot to account for the scale.
+func scaleCursorImage(imgData *draw.ImageData, hotSpot geom.Point, scale float64) (*draw.ImageData, geom.Point) {
+	if scale == 1 {
+		return imgData, hotSpot
+	}
+	img := draw.AcquireImageFromData(imgData)
+	defer img.Release()
+	surface := draw.NewImageSurface(geom.Size{Width: math.Ceil(float64(imgData.Width) * scale), Height: math.Ceil(float64(imgData.Height) * scale)})
+	defer surface.Destroy()
+	gc := draw.NewGraphics(surface.NewCairoContext())
+	gc.DrawImageInRect(img, geom.Rect{Size: surface.Size()})
+	gc.Dispose()
+	return surface.ImageData(), geom.Point{X: hotSpot.X * scale, Y: hotSpot.Y * scale}
+}
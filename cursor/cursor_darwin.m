@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 #include "cursor_darwin.h"
 
 void *ArrowCursor() {
@@ -0,0 +1,23 @@
+//go:build headless
+// +build headless
+
+package cursor
+
+import (
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+)
+
+func platformSystemCursor(id int) unsafe.Pointer {
+	return nil
+}
+
+func platformNewCursor(imgData *draw.ImageData, hotSpot geom.Point) unsafe.Pointer {
+	return nil
+}
+
+func platformDisposeCursor(cursor *Cursor) {
+	cursor.cursor = nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package cursor
 
 import (
@@ -5,6 +8,7 @@ import (
 	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/display"
 	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/internal/x11"
 )
@@ -55,6 +59,7 @@ func platformSystemCursor(id int) unsafe.Pointer {
 }
 
 func platformNewCursor(imgData *draw.ImageData, hotSpot geom.Point) unsafe.Pointer {
+	imgData, hotSpot = scaleCursorImage(imgData, hotSpot, display.Scale())
 	return unsafe.Pointer(uintptr(x11.NewCursor(imgData, hotSpot)))
 }
 
@@ -1,10 +1,38 @@
 package display
 
 import (
+	"math"
+	"os"
+	"strconv"
+
 	"github.com/richardwilkes/toolbox/xmath/geom"
 )
 
-// MainBounds returns the bounds of the main display.
+// ScaleEnvVar holds the name of the environment variable that, when set to a positive number,
+// overrides the scale factor reported by the system.
+const ScaleEnvVar = "UI_SCALE"
+
+// MainBounds returns the bounds of the main display, in logical units.
 func MainBounds() geom.Rect {
 	return platformMainDisplayBounds()
 }
+
+// Scale returns the number of device pixels per logical unit for the main display. This may be
+// overridden by setting the environment variable named by ScaleEnvVar.
+func Scale() float64 {
+	if str := os.Getenv(ScaleEnvVar); str != "" {
+		if scale, err := strconv.ParseFloat(str, 64); err == nil && scale > 0 {
+			return scale
+		}
+	}
+	if scale := platformScale(); scale > 0 {
+		return scale
+	}
+	return 1
+}
+
+// roundScale rounds a scale factor derived from a resolution to the nearest quarter, since
+// fractional scales that are not multiples of a quarter produce blurry output.
+func roundScale(scale float64) float64 {
+	return math.Max(math.Round(scale*4)/4, 1)
+}
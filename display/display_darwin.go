@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package display
 
 import (
@@ -14,3 +17,7 @@ func platformMainDisplayBounds() geom.Rect {
 	C.getMainDisplayBounds((*C.double)(&bounds.X), (*C.double)(&bounds.Y), (*C.double)(&bounds.Width), (*C.double)(&bounds.Height))
 	return bounds
 }
+
+func platformScale() float64 {
+	return float64(C.getMainDisplayScale())
+}
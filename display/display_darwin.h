@@ -1,3 +1,4 @@
 #include <Cocoa/Cocoa.h>
 
 void getMainDisplayBounds(double *x, double *y, double *width, double *height);
+double getMainDisplayScale();
@@ -7,3 +7,7 @@ void getMainDisplayBounds(double *x, double *y, double *width, double *height) {
 	*width = bounds.size.width;
 	*height = bounds.size.height;
 }
+
+double getMainDisplayScale() {
+	return [[NSScreen mainScreen] backingScaleFactor];
+}
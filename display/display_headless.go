@@ -0,0 +1,17 @@
+//go:build headless
+// +build headless
+
+package display
+
+import "github.com/richardwilkes/toolbox/xmath/geom"
+
+// HeadlessBounds holds the bounds reported for the main display when running headless.
+var HeadlessBounds = geom.Rect{Size: geom.Size{Width: 1920, Height: 1080}}
+
+func platformMainDisplayBounds() geom.Rect {
+	return HeadlessBounds
+}
+
+func platformScale() float64 {
+	return 1
+}
@@ -1,13 +1,29 @@
+//go:build !headless
+// +build !headless
+
 package display
 
-import "github.com/richardwilkes/toolbox/xmath/geom"
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/internal/x11"
+)
+
+// The resolution, in dots per inch, that corresponds to a scale of 1.
+const baseDPI = 96
 
 func platformMainDisplayBounds() geom.Rect {
-	// RAW: Replace with implementation
-	return geom.Rect{
-		Size: geom.Size{
-			Width:  1024,
-			Height: 768,
-		},
+	bounds := x11.PrimaryMonitor().Bounds
+	scale := Scale()
+	return geom.Rect{Point: geom.Point{X: bounds.X / scale, Y: bounds.Y / scale}, Size: geom.Size{Width: bounds.Width / scale, Height: bounds.Height / scale}}
+}
+
+func platformScale() float64 {
+	if dpi := x11.XftDPI(); dpi > 0 {
+		return roundScale(dpi / baseDPI)
+	}
+	monitor := x11.PrimaryMonitor()
+	if monitor.PhysicalSize.Width <= 0 {
+		return 1
 	}
+	return roundScale(monitor.Bounds.Width / (monitor.PhysicalSize.Width / 25.4) / baseDPI)
 }
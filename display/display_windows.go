@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package display
 
 import "github.com/richardwilkes/toolbox/xmath/geom"
@@ -11,3 +14,8 @@ func platformMainDisplayBounds() geom.Rect {
 		},
 	}
 }
+
+func platformScale() float64 {
+	// RAW: Implement for Windows
+	return 1
+}
@@ -0,0 +1,243 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/font"
+)
+
+// Attribute describes a style that can be applied to a run of text within an AttributedString.
+type Attribute interface {
+	pangoAttributes() []*C.PangoAttribute
+}
+
+// FamilyAttribute sets the font family.
+type FamilyAttribute string
+
+// SizeAttribute sets the font size, in points.
+type SizeAttribute float64
+
+// WeightAttribute sets the font weight.
+type WeightAttribute font.Weight
+
+// SlantAttribute sets the font slant.
+type SlantAttribute font.Slant
+
+// ForegroundAttribute sets the color of the text.
+type ForegroundAttribute color.Color
+
+// BackgroundAttribute sets the color drawn behind the text.
+type BackgroundAttribute color.Color
+
+// UnderlineAttribute controls whether the text is underlined.
+type UnderlineAttribute bool
+
+// StrikethroughAttribute controls whether the text is struck through.
+type StrikethroughAttribute bool
+
+// BaselineShiftAttribute moves the text up by the specified number of points, or down for
+// negative values.
+type BaselineShiftAttribute float64
+
+// LinkAttribute marks the text as a link to the specified target. The text is underlined, but
+// following the link is left to the widget displaying it.
+type LinkAttribute string
+
+type attributeRun struct {
+	start int
+	end   int
+	attrs []Attribute
+}
+
+// AttributedString holds text along with runs of attributes that style portions of it. Indexes
+// into the text are rune indexes.
+type AttributedString struct {
+	text   string
+	length int
+	runs   []attributeRun
+}
+
+// NewAttributedString creates a new AttributedString, with 'attrs' applied to all of the text.
+func NewAttributedString(str string, attrs ...Attribute) *AttributedString {
+	as := &AttributedString{}
+	as.Append(str, attrs...)
+	return as
+}
+
+// String implements the fmt.Stringer interface.
+func (as *AttributedString) String() string {
+	return as.text
+}
+
+// Text returns the text without any attributes.
+func (as *AttributedString) Text() string {
+	return as.text
+}
+
+// Append 'str' to the end of the text, with 'attrs' applied to it.
+func (as *AttributedString) Append(str string, attrs ...Attribute) {
+	start := as.length
+	as.text += str
+	as.length += utf8.RuneCountInString(str)
+	as.AddAttributes(start, as.length, attrs...)
+}
+
+// AddAttributes applies 'attrs' to the runes from 'start' up to, but not including, 'end'.
+// Attributes added later take precedence over earlier ones of the same kind.
+func (as *AttributedString) AddAttributes(start, end int, attrs ...Attribute) {
+	if start < 0 {
+		start = 0
+	}
+	if end > as.length {
+		end = as.length
+	}
+	if start < end && len(attrs) > 0 {
+		as.runs = append(as.runs, attributeRun{start: start, end: end, attrs: append([]Attribute(nil), attrs...)})
+	}
+}
+
+// AttributesAt returns the attributes that apply to the rune at 'index', in the order they were
+// added.
+func (as *AttributedString) AttributesAt(index int) []Attribute {
+	var attrs []Attribute
+	for _, run := range as.runs {
+		if index >= run.start && index < run.end {
+			attrs = append(attrs, run.attrs...)
+		}
+	}
+	return attrs
+}
+
+// LinkAt returns the target of the link covering the rune at 'index', or an empty string.
+func (as *AttributedString) LinkAt(index int) string {
+	var link string
+	for _, attr := range as.AttributesAt(index) {
+		if one, ok := attr.(LinkAttribute); ok {
+			link = string(one)
+		}
+	}
+	return link
+}
+
+// PangoAttrList returns a newly created PangoAttrList for the attributes. The caller is
+// responsible for releasing it with pango_attr_list_unref().
+func (as *AttributedString) PangoAttrList() unsafe.Pointer {
+	list := C.pango_attr_list_new()
+	offsets := make([]int, 0, as.length+1)
+	for i := range as.text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(as.text))
+	for _, run := range as.runs {
+		for _, attr := range run.attrs {
+			for _, pa := range attr.pangoAttributes() {
+				pa.start_index = C.guint(offsets[run.start])
+				pa.end_index = C.guint(offsets[run.end])
+				C.pango_attr_list_insert(list, pa)
+			}
+		}
+	}
+	return unsafe.Pointer(list)
+}
+
+func (attr FamilyAttribute) pangoAttributes() []*C.PangoAttribute {
+	cstr := C.CString(string(attr))
+	defer C.g_free(C.gpointer(cstr))
+	return []*C.PangoAttribute{C.pango_attr_family_new(cstr)}
+}
+
+func (attr SizeAttribute) pangoAttributes() []*C.PangoAttribute {
+	return []*C.PangoAttribute{C.pango_attr_size_new(C.int(float64(attr) * font.PangoScale))}
+}
+
+func (attr WeightAttribute) pangoAttributes() []*C.PangoAttribute {
+	return []*C.PangoAttribute{C.pango_attr_weight_new(C.PangoWeight(attr))}
+}
+
+func (attr SlantAttribute) pangoAttributes() []*C.PangoAttribute {
+	return []*C.PangoAttribute{C.pango_attr_style_new(C.PangoStyle(attr))}
+}
+
+func (attr ForegroundAttribute) pangoAttributes() []*C.PangoAttribute {
+	return pangoColorAttributes(color.Color(attr), true)
+}
+
+func (attr BackgroundAttribute) pangoAttributes() []*C.PangoAttribute {
+	return pangoColorAttributes(color.Color(attr), false)
+}
+
+func pangoColorAttributes(c color.Color, foreground bool) []*C.PangoAttribute {
+	r := C.guint16(c.Red() * 257)
+	g := C.guint16(c.Green() * 257)
+	b := C.guint16(c.Blue() * 257)
+	a := C.guint16(c.Alpha() * 257)
+	if foreground {
+		return []*C.PangoAttribute{C.pango_attr_foreground_new(r, g, b), C.pango_attr_foreground_alpha_new(a)}
+	}
+	return []*C.PangoAttribute{C.pango_attr_background_new(r, g, b), C.pango_attr_background_alpha_new(a)}
+}
+
+func (attr UnderlineAttribute) pangoAttributes() []*C.PangoAttribute {
+	if attr {
+		return []*C.PangoAttribute{C.pango_attr_underline_new(C.PANGO_UNDERLINE_SINGLE)}
+	}
+	return []*C.PangoAttribute{C.pango_attr_underline_new(C.PANGO_UNDERLINE_NONE)}
+}
+
+func (attr StrikethroughAttribute) pangoAttributes() []*C.PangoAttribute {
+	if attr {
+		return []*C.PangoAttribute{C.pango_attr_strikethrough_new(C.TRUE)}
+	}
+	return []*C.PangoAttribute{C.pango_attr_strikethrough_new(C.FALSE)}
+}
+
+func (attr BaselineShiftAttribute) pangoAttributes() []*C.PangoAttribute {
+	return []*C.PangoAttribute{C.pango_attr_rise_new(C.int(float64(attr) * font.PangoScale))}
+}
+
+func (attr LinkAttribute) pangoAttributes() []*C.PangoAttribute {
+	return []*C.PangoAttribute{C.pango_attr_underline_new(C.PANGO_UNDERLINE_SINGLE)}
+}
+
+// DrawAttributedString at the specified location using 'f' as the base font and the current fill
+// color for any text without a ForegroundAttribute.
+func (gc *Graphics) DrawAttributedString(x, y float64, str *AttributedString, f *font.Font) {
+	layout := createAttributedLayout(gc.gc, str, f)
+	gc.MoveTo(x, y+f.Leading())
+	C.pango_cairo_show_layout(gc.gc, layout)
+	C.g_object_unref(C.gpointer(layout))
+}
+
+// MeasureAttributedString returns the size of the attributed string when drawn with 'f' as the
+// base font.
+func (gc *Graphics) MeasureAttributedString(str *AttributedString, f *font.Font) geom.Size {
+	layout := createAttributedLayout(gc.gc, str, f)
+	var width, height C.int
+	C.pango_layout_get_size(layout, &width, &height)
+	C.g_object_unref(C.gpointer(layout))
+	return geom.Size{Width: float64(width) / font.PangoScale, Height: f.Leading() + float64(height)/font.PangoScale}
+}
+
+func createAttributedLayout(cc CairoContext, str *AttributedString, f *font.Font) *C.PangoLayout {
+	layout := C.pango_cairo_create_layout(cc)
+	font.ConfigurePangoContext(unsafe.Pointer(C.pango_layout_get_context(layout)))
+	C.pango_layout_set_font_description(layout, (*C.PangoFontDescription)(f.PangoFontDescription()))
+	C.pango_layout_set_spacing(layout, C.int(f.Leading()*font.PangoScale))
+	cstr := C.CString(str.text)
+	C.pango_layout_set_text(layout, cstr, -1)
+	C.g_free(C.gpointer(cstr))
+	list := (*C.PangoAttrList)(str.PangoAttrList())
+	if attr := (*C.PangoAttribute)(f.PangoFeaturesAttribute()); attr != nil {
+		// Inserted first, so that feature attributes within the string take precedence.
+		C.pango_attr_list_insert_before(list, attr)
+	}
+	C.pango_layout_set_attributes(layout, list)
+	C.pango_attr_list_unref(list)
+	return layout
+}
@@ -0,0 +1,106 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"math"
+	"unsafe"
+)
+
+// BoxBlur blurs the contents of a surface created by NewImageSurface by averaging each pixel
+// with its neighbors within 'radius' pixels, first horizontally and then vertically. Pixels
+// beyond the edges of the surface are treated as transparent. Other surface types are left
+// untouched.
+func (surface *Surface) BoxBlur(radius int) {
+	if radius < 1 {
+		return
+	}
+	surface.blur([]int{radius})
+}
+
+// Blur applies an approximation of a Gaussian blur to the contents of a surface created by
+// NewImageSurface. 'radius' is the distance in pixels over which the blur extends, roughly
+// twice the standard deviation of the Gaussian. Other surface types are left untouched.
+func (surface *Surface) Blur(radius float64) {
+	if radius <= 0 {
+		return
+	}
+	surface.blur(gaussianBoxRadii(radius/2, 3))
+}
+
+func (surface *Surface) blur(radii []int) {
+	if C.cairo_surface_get_type(surface.surface) != C.CAIRO_SURFACE_TYPE_IMAGE {
+		return
+	}
+	C.cairo_surface_flush(surface.surface)
+	width := int(C.cairo_image_surface_get_width(surface.surface))
+	height := int(C.cairo_image_surface_get_height(surface.surface))
+	stride := int(C.cairo_image_surface_get_stride(surface.surface)) / 4
+	if width == 0 || height == 0 {
+		return
+	}
+	count := stride * height
+	pixels := (*[1 << 30]uint32)(unsafe.Pointer(C.cairo_image_surface_get_data(surface.surface)))[:count:count]
+	tmp := make([]uint32, count)
+	for _, radius := range radii {
+		if radius < 1 {
+			continue
+		}
+		for y := 0; y < height; y++ {
+			boxBlurLine(pixels[y*stride:], tmp[y*stride:], width, 1, radius)
+		}
+		for x := 0; x < width; x++ {
+			boxBlurLine(tmp[x:], pixels[x:], height, stride, radius)
+		}
+	}
+	C.cairo_surface_mark_dirty(surface.surface)
+}
+
+// boxBlurLine averages 'count' premultiplied pixels spaced 'step' apart in 'src', writing the
+// results to the same positions in 'dst'.
+func boxBlurLine(src, dst []uint32, count, step, radius int) {
+	var sum [4]int
+	add := func(p uint32, sign int) {
+		sum[0] += sign * int(p>>24)
+		sum[1] += sign * int((p>>16)&0xFF)
+		sum[2] += sign * int((p>>8)&0xFF)
+		sum[3] += sign * int(p&0xFF)
+	}
+	for i := 0; i <= radius && i < count; i++ {
+		add(src[i*step], 1)
+	}
+	window := 2*radius + 1
+	half := window / 2
+	for i := 0; i < count; i++ {
+		dst[i*step] = uint32((sum[0]+half)/window)<<24 | uint32((sum[1]+half)/window)<<16 | uint32((sum[2]+half)/window)<<8 | uint32((sum[3]+half)/window)
+		if j := i + radius + 1; j < count {
+			add(src[j*step], 1)
+		}
+		if j := i - radius; j >= 0 {
+			add(src[j*step], -1)
+		}
+	}
+}
+
+// gaussianBoxRadii returns the radii of 'passes' successive box blurs that together approximate
+// a Gaussian blur with the standard deviation 'sigma'.
+func gaussianBoxRadii(sigma float64, passes int) []int {
+	n := float64(passes)
+	lower := int(math.Sqrt(12*sigma*sigma/n + 1))
+	if lower%2 == 0 {
+		lower--
+	}
+	upper := lower + 2
+	wl := float64(lower)
+	m := int(math.Round((12*sigma*sigma - n*wl*wl - 4*n*wl - 3*n) / (-4*wl - 4)))
+	radii := make([]int, passes)
+	for i := range radii {
+		if i < m {
+			radii[i] = (lower - 1) / 2
+		} else {
+			radii[i] = (upper - 1) / 2
+		}
+	}
+	return radii
+}
@@ -0,0 +1,117 @@
+package draw
+
+import (
+	"math"
+
+	"github.com/richardwilkes/ui/color"
+)
+
+// ColorMatrix is a 4x5 matrix that transforms colors. Each row produces one of the red, green,
+// blue and alpha channels of the result, in that order, from the intensities (0-1) of the red,
+// green, blue and alpha channels of the source plus a constant offset in the fifth column.
+type ColorMatrix [20]float64
+
+// IdentityColorMatrix returns a ColorMatrix that leaves colors unchanged.
+func IdentityColorMatrix() ColorMatrix {
+	return ColorMatrix{
+		1, 0, 0, 0, 0,
+		0, 1, 0, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 0, 1, 0,
+	}
+}
+
+// GrayscaleColorMatrix returns a ColorMatrix that replaces colors with their luminance.
+func GrayscaleColorMatrix() ColorMatrix {
+	return ColorMatrix{
+		0.299, 0.587, 0.114, 0, 0,
+		0.299, 0.587, 0.114, 0, 0,
+		0.299, 0.587, 0.114, 0, 0,
+		0, 0, 0, 1, 0,
+	}
+}
+
+// TintColorMatrix returns a ColorMatrix that shifts colors towards shades of 'tint'. 'amount'
+// ranges from 0 (no change) to 1 (only shades of the tint remain).
+func TintColorMatrix(tint color.Color, amount float64) ColorMatrix {
+	r := tint.RedIntensity()
+	g := tint.GreenIntensity()
+	b := tint.BlueIntensity()
+	tinted := ColorMatrix{
+		0.299 * r, 0.587 * r, 0.114 * r, 0, 0,
+		0.299 * g, 0.587 * g, 0.114 * g, 0, 0,
+		0.299 * b, 0.587 * b, 0.114 * b, 0, 0,
+		0, 0, 0, 1, 0,
+	}
+	return IdentityColorMatrix().mix(tinted, amount)
+}
+
+// BrightnessColorMatrix returns a ColorMatrix that adds 'amount', from -1 to 1, to each color
+// channel.
+func BrightnessColorMatrix(amount float64) ColorMatrix {
+	m := IdentityColorMatrix()
+	m[4] = amount
+	m[9] = amount
+	m[14] = amount
+	return m
+}
+
+// OpacityColorMatrix returns a ColorMatrix that multiplies the alpha channel by 'opacity'.
+func OpacityColorMatrix(opacity float64) ColorMatrix {
+	m := IdentityColorMatrix()
+	m[18] = opacity
+	return m
+}
+
+// DisabledColorMatrix returns the ColorMatrix used by Image.AcquireDisabled(), which desaturates
+// and ghosts colors.
+func DisabledColorMatrix() ColorMatrix {
+	return GrayscaleColorMatrix().Then(OpacityColorMatrix(0.4))
+}
+
+// Then returns a ColorMatrix that is equivalent to applying this matrix followed by 'other'.
+func (m ColorMatrix) Then(other ColorMatrix) ColorMatrix {
+	var result ColorMatrix
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 5; col++ {
+			var v float64
+			for k := 0; k < 4; k++ {
+				v += other[row*5+k] * m[k*5+col]
+			}
+			if col == 4 {
+				v += other[row*5+4]
+			}
+			result[row*5+col] = v
+		}
+	}
+	return result
+}
+
+func (m ColorMatrix) mix(other ColorMatrix, amount float64) ColorMatrix {
+	amount = math.Max(math.Min(amount, 1), 0)
+	for i := range m {
+		m[i] += (other[i] - m[i]) * amount
+	}
+	return m
+}
+
+// Apply returns the result of transforming the color 'c' by this matrix.
+func (m ColorMatrix) Apply(c color.Color) color.Color {
+	in := [4]float64{c.RedIntensity(), c.GreenIntensity(), c.BlueIntensity(), c.AlphaIntensity()}
+	var out [4]float64
+	for row := 0; row < 4; row++ {
+		v := m[row*5+4]
+		for k := 0; k < 4; k++ {
+			v += m[row*5+k] * in[k]
+		}
+		out[row] = v
+	}
+	return color.RGBAfloat(out[0], out[1], out[2], out[3])
+}
+
+// ApplyToImageData transforms each pixel of 'data' by this matrix, in place.
+func (m ColorMatrix) ApplyToImageData(data *ImageData) {
+	for i, p := range data.Pixels {
+		data.Pixels[i] = m.Apply(p)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	// #include <pango/pangocairo.h>
 	"C"
 	"math"
+	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
@@ -95,6 +96,11 @@ func NewGraphics(cc CairoContext) *Graphics {
 	return gc
 }
 
+// CairoContext returns the underlying Cairo graphics context.
+func (gc *Graphics) CairoContext() CairoContext {
+	return gc.gc
+}
+
 // Dispose of the underlying Cairo graphics context
 func (gc *Graphics) Dispose() {
 	C.cairo_destroy(gc.gc)
@@ -122,6 +128,12 @@ func (gc *Graphics) Scale(x, y float64) {
 	C.cairo_scale(gc.gc, C.double(x), C.double(y))
 }
 
+// DeviceScale returns the number of device pixels covered by one unit in the current coordinate
+// system.
+func (gc *Graphics) DeviceScale() float64 {
+	return math.Hypot(gc.UserToDeviceDistance(1, 0))
+}
+
 // Rotate the coordinate system.
 func (gc *Graphics) Rotate(angleInRadians float64) {
 	C.cairo_rotate(gc.gc, C.double(angleInRadians))
@@ -329,6 +341,12 @@ func (gc *Graphics) SetSurface(surface *Surface, dx, dy float64) {
 	C.cairo_pattern_set_extend(C.cairo_get_source(gc.gc), C.CAIRO_EXTEND_REPEAT)
 }
 
+// CreateSimilarSurface creates a new surface similar to the one this graphics context draws
+// into, but with the specified content type and size in device pixels.
+func (gc *Graphics) CreateSimilarSurface(contentType CairoContentType, size geom.Size) *Surface {
+	return &Surface{surface: C.cairo_surface_create_similar(C.cairo_get_target(gc.gc), C.cairo_content_t(contentType), C.int(size.Width), C.int(size.Height)), size: size}
+}
+
 // AntiAlias returns the current anti-aliasing mode.
 func (gc *Graphics) AntiAlias() AntiAliasKind {
 	return AntiAliasKind(C.cairo_get_antialias(gc.gc))
@@ -581,27 +599,45 @@ func (gc *Graphics) DrawImage(img *Image, where geom.Point) {
 	gc.DrawImageInRect(img, geom.Rect{Point: where, Size: img.Size()})
 }
 
-// DrawImageInRect draws the image in the bounds, scaling if necessary.
+// DrawImageInRect draws the image in the bounds, scaling if necessary. If the image has higher
+// resolution variants, the one best suited to the context's device scale is used.
 func (gc *Graphics) DrawImageInRect(img *Image, bounds geom.Rect) {
 	gc.Save()
 	gc.Rect(bounds)
 	gc.Clip()
 	gc.Translate(bounds.X, bounds.Y)
 	size := img.Size()
-	gc.Scale(bounds.Width/size.Width, bounds.Height/size.Height)
+	img = img.BestVariant(gc.DeviceScale() * math.Max(bounds.Width/size.Width, bounds.Height/size.Height))
+	gc.Scale(bounds.Width/float64(img.width), bounds.Height/float64(img.height))
 	C.cairo_set_source_surface(gc.gc, img.surface, 0, 0)
 	gc.FillClip()
 	gc.Restore()
 }
 
+// DrawSVG at the specified location, at its intrinsic size.
+func (gc *Graphics) DrawSVG(svg *SVG, where geom.Point) {
+	svg.Draw(gc, geom.Rect{Point: where, Size: svg.Size()})
+}
+
+// DrawSVGInRect draws the SVG in the bounds, scaling as necessary. Since the SVG is rendered as
+// vectors, it remains sharp at any scale.
+func (gc *Graphics) DrawSVGInRect(svg *SVG, bounds geom.Rect) {
+	svg.Draw(gc, bounds)
+}
+
 // DrawString at the specified location using the current font and fill color.
 func (gc *Graphics) DrawString(x, y float64, str string, f *font.Font) {
 	layout := C.pango_cairo_create_layout(gc.gc)
+	font.ConfigurePangoContext(unsafe.Pointer(C.pango_layout_get_context(layout)))
 	C.pango_layout_set_font_description(layout, (*C.PangoFontDescription)(f.PangoFontDescription()))
 	C.pango_layout_set_spacing(layout, C.int(f.Leading()*font.PangoScale))
 	cstr := C.CString(str)
 	C.pango_layout_set_text(layout, cstr, -1)
 	C.g_free(C.gpointer(cstr))
+	if list := (*C.PangoAttrList)(f.PangoAttrList()); list != nil {
+		C.pango_layout_set_attributes(layout, list)
+		C.pango_attr_list_unref(list)
+	}
 	gc.MoveTo(x, y+f.Leading())
 	C.pango_cairo_show_layout(gc.gc, layout)
 	var inkRect, logicalRect C.PangoRectangle
@@ -11,6 +11,10 @@ import (
 	_ "image/png"  // Support loading of PNG
 	"io"
 	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -30,16 +34,24 @@ type fsKey struct {
 	path string
 }
 
-// Image represents a set of pixels that can be drawn to a graphics.Context.
+// Image represents a set of pixels that can be drawn to a graphics.Context. An image may have
+// higher resolution variants, which are chosen automatically when drawing to a scaled context.
 type Image struct {
 	object.Base
 	disabledID uint64
 	width      int
 	height     int
+	scale      float64
 	surface    *C.cairo_surface_t
 	key        interface{}
+	variants   []*Image
 }
 
+// VariantScales holds the scales for which higher resolution variants are looked for when loading
+// an image from a file. For a scale of 2 and a file named "icon.png", the variant is expected to
+// be named "icon@2x.png".
+var VariantScales = []float64{2, 3}
+
 var (
 	imageRegistryLock sync.Mutex
 	imageRegistry     = make(map[interface{}]*imgRef)
@@ -66,11 +78,40 @@ func loadFromStream(key interface{}, stream io.ReadCloser) (ref *imgRef, err err
 		}
 	}
 	C.cairo_surface_mark_dirty(surface)
-	img := &Image{width: bounds.Dx(), height: bounds.Dy(), surface: surface, key: key}
+	img := &Image{width: bounds.Dx(), height: bounds.Dy(), scale: 1, surface: surface, key: key}
 	img.InitTypeAndID(img)
 	return &imgRef{img: img}, nil
 }
 
+// variantPath returns the path to the variant of the image at 'p' for the specified scale.
+func variantPath(p string, scale float64) string {
+	ext := path.Ext(p)
+	return fmt.Sprintf("%s@%sx%s", strings.TrimSuffix(p, ext), strconv.FormatFloat(scale, 'f', -1, 64), ext)
+}
+
+// scaleFromPath returns the scale embedded in an image's file name, such as the 2 in
+// "icon@2x.png", or 1 if there isn't one.
+func scaleFromPath(p string) float64 {
+	base := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	if i := strings.LastIndex(base, "@"); i != -1 && strings.HasSuffix(base, "x") {
+		if scale, err := strconv.ParseFloat(base[i+1:len(base)-1], 64); err == nil && scale > 0 {
+			return scale
+		}
+	}
+	return 1
+}
+
+func loadVariants(fs http.FileSystem, p string, img *Image) {
+	for _, scale := range VariantScales {
+		vp := variantPath(p, scale)
+		if file, err := fs.Open(vp); err == nil {
+			if ref, err := loadFromStream(fsKey{fs: fs, path: vp}, file); err == nil {
+				img.AddVariant(ref.img, scale)
+			}
+		}
+	}
+}
+
 // AcquireImageFromFile attempts to load an image from the file system.
 func AcquireImageFromFile(fs http.FileSystem, path string) (img *Image, err error) {
 	imageRegistryLock.Lock()
@@ -86,6 +127,9 @@ func AcquireImageFromFile(fs http.FileSystem, path string) (img *Image, err erro
 		if ref, err = loadFromStream(key, file); err != nil {
 			return nil, err
 		}
+		if ref.img.scale = scaleFromPath(path); ref.img.scale == 1 {
+			loadVariants(fs, path, ref.img)
+		}
 		imageRegistry[key] = ref
 	}
 	ref.count++
@@ -135,7 +179,7 @@ func AcquireImageFromData(data *ImageData) *Image {
 		}
 	}
 	C.cairo_surface_mark_dirty(surface)
-	img := &Image{width: data.Width, height: data.Height, surface: surface}
+	img := &Image{width: data.Width, height: data.Height, scale: 1, surface: surface}
 	img.InitTypeAndID(img)
 	img.key = img.ID()
 	ref := &imgRef{img: img, count: 1}
@@ -147,7 +191,7 @@ func AcquireImageFromData(data *ImageData) *Image {
 
 // NewImage creates a new image.
 func NewImage(width, height int) *Image {
-	img := &Image{width: width, height: height, surface: C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, C.int(width), C.int(height))}
+	img := &Image{width: width, height: height, scale: 1, surface: C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, C.int(width), C.int(height))}
 	img.InitTypeAndID(img)
 	img.key = img.ID()
 	ref := &imgRef{img: img, count: 1}
@@ -169,22 +213,65 @@ func (img *Image) AcquireDisabled() *Image {
 	if image != nil {
 		return image
 	}
-	data := img.Data()
-	for i := range data.Pixels {
-		p := data.Pixels[i]
-		v := int((p.Luminance() * 255) + 0.5)
-		data.Pixels[i] = color.RGBA(v, v, v, p.AlphaIntensity()*0.4)
-	}
-	image = AcquireImageFromData(data)
+	image = img.AcquireFiltered(DisabledColorMatrix())
 	img.disabledID = image.ID()
 	return image
 }
 
-// Size returns the size of the image.
+// AcquireFiltered returns a new image based on this image, and any of its variants, with each
+// pixel transformed by 'matrix'.
+func (img *Image) AcquireFiltered(matrix ColorMatrix) *Image {
+	image := AcquireImageFromData(filteredData(img.Data(), matrix))
+	image.scale = img.scale
+	for _, variant := range img.variants {
+		filtered := AcquireImageFromData(filteredData(variant.Data(), matrix))
+		image.AddVariant(filtered, variant.scale/img.scale)
+	}
+	return image
+}
+
+func filteredData(data *ImageData, matrix ColorMatrix) *ImageData {
+	matrix.ApplyToImageData(data)
+	return data
+}
+
+// AddVariant adds a higher resolution version of this image, which should contain 'scale' pixels
+// for every pixel of this image. The image takes over the caller's reference to the variant.
+func (img *Image) AddVariant(variant *Image, scale float64) {
+	variant.scale = scale * img.scale
+	img.variants = append(img.variants, variant)
+	sort.Slice(img.variants, func(i, j int) bool { return img.variants[i].scale < img.variants[j].scale })
+}
+
+// BestVariant returns the image, or one of its variants, best suited to drawing with the
+// specified number of device pixels per logical unit.
+func (img *Image) BestVariant(density float64) *Image {
+	best := img
+	for _, variant := range img.variants {
+		if best.scale >= density {
+			break
+		}
+		best = variant
+	}
+	return best
+}
+
+// Size returns the logical size of the image. For an image loaded from a file whose name has a
+// scale suffix, such as "icon@2x.png", this is its size in pixels divided by the scale.
 func (img *Image) Size() geom.Size {
+	return geom.Size{Width: float64(img.width) / img.scale, Height: float64(img.height) / img.scale}
+}
+
+// PixelSize returns the size of the image in pixels.
+func (img *Image) PixelSize() geom.Size {
 	return geom.Size{Width: float64(img.width), Height: float64(img.height)}
 }
 
+// Scale returns the number of pixels per logical unit in the image.
+func (img *Image) Scale() float64 {
+	return img.scale
+}
+
 // Data extracts the raw image data.
 func (img *Image) Data() *ImageData {
 	data := &ImageData{Width: img.width, Height: img.height, Pixels: make([]color.Color, img.width*img.height)}
@@ -234,6 +321,10 @@ func (img *Image) NewCairoContext() CairoContext {
 func (img *Image) Release() {
 	imageRegistryLock.Lock()
 	defer imageRegistryLock.Unlock()
+	img.releaseLocked()
+}
+
+func (img *Image) releaseLocked() {
 	if ref, ok := imageRegistry[img.key]; ok {
 		ref.count--
 		if ref.count > 0 {
@@ -241,6 +332,11 @@ func (img *Image) Release() {
 		}
 		delete(imageRegistry, img.key)
 	}
+	variants := img.variants
+	img.variants = nil
+	for _, variant := range variants {
+		variant.releaseLocked()
+	}
 	if img.surface != nil {
 		C.cairo_surface_destroy(img.surface)
 		img.surface = nil
@@ -0,0 +1,141 @@
+package draw
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/font"
+)
+
+// ParseMarkup creates an AttributedString from a small subset of HTML-like markup. The supported
+// tags are:
+//
+//   - <b> for bold text
+//   - <i> for italic text
+//   - <u> for underlined text
+//   - <s> for struck through text
+//   - <sup> and <sub> for superscript and subscript text
+//   - <a href="target"> for links
+//   - <span> with any of the attributes "font" (family), "size" (in points), "weight" (a number
+//     from 100 to 1000, or "bold"), "color" and "background" (in any format accepted by
+//     color.Decode), "rise" (in points), "underline" and "strikethrough" ("true" or "false")
+//
+// The standard XML entities, such as &lt; and &amp;, may be used to include special characters.
+func ParseMarkup(markup string) (*AttributedString, error) {
+	as := &AttributedString{}
+	decoder := xml.NewDecoder(strings.NewReader("<markup>" + markup + "</markup>"))
+	var stack [][]Attribute
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errs.NewWithCause("invalid markup", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && t.Name.Local == "markup" {
+				stack = append(stack, nil)
+				continue
+			}
+			attrs, err := markupAttributes(t)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, attrs)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			var attrs []Attribute
+			for _, one := range stack {
+				attrs = append(attrs, one...)
+			}
+			as.Append(string(t), attrs...)
+		}
+	}
+	return as, nil
+}
+
+func markupAttributes(element xml.StartElement) ([]Attribute, error) {
+	switch element.Name.Local {
+	case "b":
+		return []Attribute{WeightAttribute(font.WeightBold)}, nil
+	case "i":
+		return []Attribute{SlantAttribute(font.SlantItalic)}, nil
+	case "u":
+		return []Attribute{UnderlineAttribute(true)}, nil
+	case "s":
+		return []Attribute{StrikethroughAttribute(true)}, nil
+	case "sup":
+		return []Attribute{BaselineShiftAttribute(4)}, nil
+	case "sub":
+		return []Attribute{BaselineShiftAttribute(-2)}, nil
+	case "a":
+		for _, one := range element.Attr {
+			if one.Name.Local == "href" {
+				return []Attribute{LinkAttribute(one.Value)}, nil
+			}
+		}
+		return nil, errs.New("link without href in markup")
+	case "span":
+		attrs := make([]Attribute, 0, len(element.Attr))
+		for _, one := range element.Attr {
+			attr, err := spanAttribute(one)
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, attr)
+		}
+		return attrs, nil
+	default:
+		return nil, errs.Newf("unsupported markup tag: %s", element.Name.Local)
+	}
+}
+
+func spanAttribute(attr xml.Attr) (Attribute, error) {
+	switch attr.Name.Local {
+	case "font":
+		return FamilyAttribute(attr.Value), nil
+	case "size":
+		size, err := strconv.ParseFloat(attr.Value, 64)
+		if err != nil {
+			return nil, errs.NewfWithCause(err, "invalid size in markup: %s", attr.Value)
+		}
+		return SizeAttribute(size), nil
+	case "weight":
+		if attr.Value == "bold" {
+			return WeightAttribute(font.WeightBold), nil
+		}
+		weight, err := strconv.Atoi(attr.Value)
+		if err != nil {
+			return nil, errs.NewfWithCause(err, "invalid weight in markup: %s", attr.Value)
+		}
+		return WeightAttribute(weight), nil
+	case "color":
+		return ForegroundAttribute(color.Decode(attr.Value)), nil
+	case "background":
+		return BackgroundAttribute(color.Decode(attr.Value)), nil
+	case "rise":
+		rise, err := strconv.ParseFloat(attr.Value, 64)
+		if err != nil {
+			return nil, errs.NewfWithCause(err, "invalid rise in markup: %s", attr.Value)
+		}
+		return BaselineShiftAttribute(rise), nil
+	case "underline", "strikethrough":
+		on, err := strconv.ParseBool(attr.Value)
+		if err != nil {
+			return nil, errs.NewfWithCause(err, "invalid %s in markup: %s", attr.Name.Local, attr.Value)
+		}
+		if attr.Name.Local == "underline" {
+			return UnderlineAttribute(on), nil
+		}
+		return StrikethroughAttribute(on), nil
+	default:
+		return nil, errs.Newf("unsupported span attribute in markup: %s", attr.Name.Local)
+	}
+}
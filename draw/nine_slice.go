@@ -0,0 +1,116 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// NineSlice divides an image into nine parts using a set of insets, so that it can be drawn into
+// rectangles of any size without distorting its corners. The corners are always drawn at their
+// natural size, the edges are stretched or tiled along their length, and the centre is stretched
+// or tiled in both directions.
+type NineSlice struct {
+	image  *Image
+	insets geom.Insets
+	// TileEdges causes the edges to be repeated rather than stretched.
+	TileEdges bool
+	// TileCenter causes the centre to be repeated rather than stretched.
+	TileCenter bool
+}
+
+// NewNineSlice creates a new nine-slice image from 'img'. The insets, in the image's logical
+// units, describe the size of the fixed corners and edges. The nine-slice does not take over
+// the caller's reference to the image, so the image must not be released while the nine-slice
+// is in use.
+func NewNineSlice(img *Image, insets geom.Insets) *NineSlice {
+	return &NineSlice{image: img, insets: insets}
+}
+
+// Image returns the underlying image.
+func (ns *NineSlice) Image() *Image {
+	return ns.image
+}
+
+// Insets returns the insets describing the fixed corners and edges.
+func (ns *NineSlice) Insets() geom.Insets {
+	return ns.insets
+}
+
+// Draw the nine-slice image into 'bounds'.
+func (ns *NineSlice) Draw(gc *Graphics, bounds geom.Rect) {
+	ns.draw(gc, bounds, true)
+}
+
+// DrawEdges draws the corners and edges of the nine-slice image into 'bounds', leaving the
+// centre untouched.
+func (ns *NineSlice) DrawEdges(gc *Graphics, bounds geom.Rect) {
+	ns.draw(gc, bounds, false)
+}
+
+func (ns *NineSlice) draw(gc *Graphics, bounds geom.Rect, center bool) {
+	if bounds.IsEmpty() {
+		return
+	}
+	size := ns.image.Size()
+	img := ns.image.BestVariant(gc.DeviceScale())
+	density := float64(img.width) / size.Width
+	src := sliceOffsets(ns.insets.Left, size.Width-ns.insets.Right, size.Width)
+	srcY := sliceOffsets(ns.insets.Top, size.Height-ns.insets.Bottom, size.Height)
+	dst := destinationOffsets(bounds.X, bounds.Width, ns.insets.Left, ns.insets.Right)
+	dstY := destinationOffsets(bounds.Y, bounds.Height, ns.insets.Top, ns.insets.Bottom)
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			middle := row == 1 && col == 1
+			if middle && !center {
+				continue
+			}
+			from := geom.Rect{Point: geom.Point{X: src[col] * density, Y: srcY[row] * density}, Size: geom.Size{Width: (src[col+1] - src[col]) * density, Height: (srcY[row+1] - srcY[row]) * density}}
+			to := geom.Rect{Point: geom.Point{X: dst[col], Y: dstY[row]}, Size: geom.Size{Width: dst[col+1] - dst[col], Height: dstY[row+1] - dstY[row]}}
+			tile := (middle && ns.TileCenter) || (!middle && (row == 1 || col == 1) && ns.TileEdges)
+			gc.drawImageSlice(img, from, to, density, tile)
+		}
+	}
+}
+
+func sliceOffsets(first, second, length float64) [4]float64 {
+	return [4]float64{0, first, math.Max(first, second), length}
+}
+
+// destinationOffsets returns the offsets along one axis at which the slices are placed, shrinking
+// the fixed portions proportionally if there isn't room for them.
+func destinationOffsets(start, length, first, last float64) [4]float64 {
+	if total := first + last; total > length && total > 0 {
+		first = length * first / total
+		last = length - first
+	}
+	return [4]float64{start, start + first, start + length - last, start + length}
+}
+
+func (gc *Graphics) drawImageSlice(img *Image, src, dst geom.Rect, density float64, tile bool) {
+	if src.Width < 1 || src.Height < 1 || dst.IsEmpty() {
+		return
+	}
+	sub := C.cairo_surface_create_for_rectangle(img.surface, C.double(src.X), C.double(src.Y), C.double(src.Width), C.double(src.Height))
+	gc.Save()
+	gc.Rect(dst)
+	gc.Clip()
+	gc.Translate(dst.X, dst.Y)
+	if tile {
+		gc.Scale(1/density, 1/density)
+	} else {
+		gc.Scale(dst.Width/src.Width, dst.Height/src.Height)
+	}
+	C.cairo_set_source_surface(gc.gc, sub, 0, 0)
+	if tile {
+		C.cairo_pattern_set_extend(C.cairo_get_source(gc.gc), C.CAIRO_EXTEND_REPEAT)
+	} else {
+		C.cairo_pattern_set_extend(C.cairo_get_source(gc.gc), C.CAIRO_EXTEND_PAD)
+	}
+	gc.FillClip()
+	gc.Restore()
+	C.cairo_surface_destroy(sub)
+}
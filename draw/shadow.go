@@ -0,0 +1,92 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/compositing"
+)
+
+// DrawShadow draws a blurred shadow of 'path' filled with 'color', displaced by 'offset'. 'blur'
+// is the radius of the blur, in user space units. The current path is cleared.
+func (gc *Graphics) DrawShadow(path *Path, offset geom.Point, blur float64, color color.Color) {
+	bounds := gc.pathBounds(path)
+	if bounds.IsEmpty() {
+		return
+	}
+	bounds.InsetUniform(-math.Max(blur, 0))
+	surface, scale := gc.shadowSurface(bounds)
+	sgc := shadowGraphics(surface, bounds, scale)
+	sgc.AddPath(path)
+	sgc.SetColor(color)
+	sgc.FillPath()
+	sgc.Dispose()
+	surface.Blur(blur * scale)
+	bounds.X += offset.X
+	bounds.Y += offset.Y
+	gc.paintShadow(surface, bounds, scale)
+	surface.Destroy()
+}
+
+// DrawInnerShadow draws a blurred shadow with 'color' inside 'path', as if the area outside of
+// the path were casting a shadow displaced by 'offset'. 'blur' is the radius of the blur, in user
+// space units. The current path is cleared.
+func (gc *Graphics) DrawInnerShadow(path *Path, offset geom.Point, blur float64, color color.Color) {
+	bounds := gc.pathBounds(path)
+	if bounds.IsEmpty() {
+		return
+	}
+	bounds.InsetUniform(-(math.Max(blur, 0) + math.Max(math.Abs(offset.X), math.Abs(offset.Y))))
+	surface, scale := gc.shadowSurface(bounds)
+	sgc := shadowGraphics(surface, bounds, scale)
+	sgc.SetColor(color)
+	sgc.FillClip()
+	sgc.Translate(offset.X, offset.Y)
+	sgc.AddPath(path)
+	sgc.SetCompositingOperator(compositing.Clear)
+	sgc.FillPath()
+	sgc.Dispose()
+	surface.Blur(blur * scale)
+	gc.Save()
+	gc.BeginPath()
+	gc.AddPath(path)
+	gc.Clip()
+	gc.paintShadow(surface, bounds, scale)
+	gc.Restore()
+	surface.Destroy()
+}
+
+func (gc *Graphics) pathBounds(path *Path) geom.Rect {
+	gc.BeginPath()
+	gc.AddPath(path)
+	var x1, y1, x2, y2 C.double
+	C.cairo_path_extents(gc.gc, &x1, &y1, &x2, &y2)
+	gc.BeginPath()
+	return geom.Rect{Point: geom.Point{X: float64(x1), Y: float64(y1)}, Size: geom.Size{Width: float64(x2 - x1), Height: float64(y2 - y1)}}
+}
+
+func (gc *Graphics) shadowSurface(bounds geom.Rect) (surface *Surface, scale float64) {
+	scale = gc.DeviceScale()
+	return NewImageSurface(geom.Size{Width: math.Ceil(bounds.Width * scale), Height: math.Ceil(bounds.Height * scale)}), scale
+}
+
+func shadowGraphics(surface *Surface, bounds geom.Rect, scale float64) *Graphics {
+	gc := NewGraphics(surface.NewCairoContext())
+	gc.Scale(scale, scale)
+	gc.Translate(-bounds.X, -bounds.Y)
+	return gc
+}
+
+func (gc *Graphics) paintShadow(surface *Surface, bounds geom.Rect, scale float64) {
+	gc.Save()
+	gc.Translate(bounds.X, bounds.Y)
+	gc.Scale(1/scale, 1/scale)
+	gc.Rect(geom.Rect{Size: surface.Size()})
+	gc.SetSurface(surface, 0, 0)
+	gc.FillPath()
+	gc.Restore()
+}
@@ -0,0 +1,58 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+type streamWriter struct {
+	writer io.Writer
+	err    error
+}
+
+var (
+	streamLock   sync.Mutex
+	nextStreamID uint64 = 1
+	streams             = make(map[uint64]*streamWriter)
+)
+
+func registerStream(w io.Writer) uint64 {
+	streamLock.Lock()
+	id := nextStreamID
+	nextStreamID++
+	streams[id] = &streamWriter{writer: w}
+	streamLock.Unlock()
+	return id
+}
+
+func unregisterStream(id uint64) error {
+	streamLock.Lock()
+	sw := streams[id]
+	delete(streams, id)
+	streamLock.Unlock()
+	if sw != nil {
+		return sw.err
+	}
+	return nil
+}
+
+//export drawWriteToStream
+func drawWriteToStream(closure unsafe.Pointer, data *C.uchar, length C.uint) C.cairo_status_t {
+	streamLock.Lock()
+	sw := streams[uint64(uintptr(closure))]
+	streamLock.Unlock()
+	if sw == nil || sw.err != nil {
+		return C.CAIRO_STATUS_WRITE_ERROR
+	}
+	if _, err := sw.writer.Write(C.GoBytes(unsafe.Pointer(data), C.int(length))); err != nil {
+		sw.err = errs.Wrap(err)
+		return C.CAIRO_STATUS_WRITE_ERROR
+	}
+	return C.CAIRO_STATUS_SUCCESS
+}
@@ -4,8 +4,10 @@ import (
 	// #cgo pkg-config: pangocairo
 	// #include <pango/pangocairo.h>
 	"C"
+	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
 )
 
 // CairoContentType holds the type of content for a surface.
@@ -22,6 +24,12 @@ const (
 type Surface struct {
 	surface *C.cairo_surface_t
 	size    geom.Size
+	stream  uint64
+}
+
+// NewImageSurface creates a new surface backed by an in-memory image of the specified size.
+func NewImageSurface(size geom.Size) *Surface {
+	return &Surface{surface: C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, C.int(size.Width), C.int(size.Height)), size: size}
 }
 
 // Size returns the size in pixels of the surface.
@@ -32,6 +40,10 @@ func (surface *Surface) Size() geom.Size {
 // Destroy a surface.
 func (surface *Surface) Destroy() {
 	C.cairo_surface_destroy(surface.surface)
+	if surface.stream != 0 {
+		unregisterStream(surface.stream)
+		surface.stream = 0
+	}
 }
 
 // NewCairoContext creates a new CairoContext.
@@ -44,3 +56,19 @@ func (surface *Surface) NewCairoContext() CairoContext {
 func (surface *Surface) CreateSimilar(contentType CairoContentType, size geom.Size) *Surface {
 	return &Surface{surface: C.cairo_surface_create_similar(surface.surface, C.cairo_content_t(contentType), C.int(size.Width), C.int(size.Height)), size: size}
 }
+
+// ImageData extracts the raw image data from a surface created by NewImageSurface.
+func (surface *Surface) ImageData() *ImageData {
+	C.cairo_surface_flush(surface.surface)
+	width := int(C.cairo_image_surface_get_width(surface.surface))
+	height := int(C.cairo_image_surface_get_height(surface.surface))
+	data := &ImageData{Width: width, Height: height, Pixels: make([]color.Color, width*height)}
+	stride := int(C.cairo_image_surface_get_stride(surface.surface)) / 4
+	pixels := (*[1 << 30]color.Color)(unsafe.Pointer(C.cairo_image_surface_get_data(surface.surface)))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			data.Pixels[y*width+x] = pixels[y*stride+x].Unpremultiply()
+		}
+	}
+	return data
+}
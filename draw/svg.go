@@ -0,0 +1,270 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo librsvg-2.0
+	// #include <pango/pangocairo.h>
+	// #include <librsvg/rsvg.h>
+	"C"
+	"container/list"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sync"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/object"
+)
+
+type svgRef struct {
+	svg   *SVG
+	count int
+}
+
+// maxCachedRasters is the number of rasters each SVG keeps. Widgets typically need only one or two
+// sizes, each possibly with a disabled variant, but live resizing can request many sizes in quick
+// succession.
+const maxCachedRasters = 8
+
+type rasterKey struct {
+	width    int
+	height   int
+	disabled bool
+}
+
+type raster struct {
+	key rasterKey
+	img *Image
+}
+
+// SVG represents a vector image in the Scalable Vector Graphics format. Unlike an Image, it can
+// be drawn at any size without loss of quality. Paths, fills, strokes, gradients, transforms and
+// basic text are supported.
+type SVG struct {
+	object.Base
+	handle      *C.RsvgHandle
+	size        geom.Size
+	key         interface{}
+	rasterLock  sync.Mutex
+	rasterCache map[rasterKey]*list.Element
+	rasterOrder *list.List
+}
+
+var (
+	svgRegistryLock sync.Mutex
+	svgRegistry     = make(map[interface{}]*svgRef)
+)
+
+func loadSVGFromStream(key interface{}, stream io.ReadCloser) (ref *svgRef, err error) {
+	defer func() {
+		if serr := stream.Close(); serr != nil && err == nil {
+			err = errs.Wrap(serr)
+		}
+	}()
+	var data []byte
+	if data, err = ioutil.ReadAll(stream); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var svg *SVG
+	if svg, err = newSVG(key, data); err != nil {
+		return nil, err
+	}
+	return &svgRef{svg: svg}, nil
+}
+
+func newSVG(key interface{}, data []byte) (*SVG, error) {
+	if len(data) == 0 {
+		return nil, errs.New("no SVG data")
+	}
+	var gerr *C.GError
+	handle := C.rsvg_handle_new_from_data((*C.guint8)(unsafe.Pointer(&data[0])), C.gsize(len(data)), &gerr)
+	if handle == nil {
+		msg := "unable to load SVG"
+		if gerr != nil {
+			msg = C.GoString(gerr.message)
+			C.g_error_free(gerr)
+		}
+		return nil, errs.New(msg)
+	}
+	var dim C.RsvgDimensionData
+	C.rsvg_handle_get_dimensions(handle, &dim)
+	svg := &SVG{handle: handle, size: geom.Size{Width: float64(dim.width), Height: float64(dim.height)}, key: key, rasterCache: make(map[rasterKey]*list.Element), rasterOrder: list.New()}
+	svg.InitTypeAndID(svg)
+	if svg.key == nil {
+		svg.key = svg.ID()
+	}
+	return svg, nil
+}
+
+// AcquireSVGFromFile attempts to load an SVG from the file system.
+func AcquireSVGFromFile(fs http.FileSystem, path string) (svg *SVG, err error) {
+	svgRegistryLock.Lock()
+	defer svgRegistryLock.Unlock()
+	var ref *svgRef
+	var ok bool
+	key := fsKey{fs: fs, path: path}
+	if ref, ok = svgRegistry[key]; !ok {
+		var file http.File
+		if file, err = fs.Open(path); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		if ref, err = loadSVGFromStream(key, file); err != nil {
+			return nil, err
+		}
+		svgRegistry[key] = ref
+	}
+	ref.count++
+	return ref.svg, nil
+}
+
+// AcquireSVGFromURL attempts to load an SVG from a URL.
+func AcquireSVGFromURL(url string) (svg *SVG, err error) {
+	svgRegistryLock.Lock()
+	defer svgRegistryLock.Unlock()
+	var ref *svgRef
+	var ok bool
+	if ref, ok = svgRegistry[url]; !ok {
+		var resp *http.Response
+		if resp, err = http.Get(url); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		if ref, err = loadSVGFromStream(url, resp.Body); err != nil {
+			return nil, err
+		}
+		svgRegistry[url] = ref
+	}
+	ref.count++
+	return ref.svg, nil
+}
+
+// AcquireSVGFromData creates a new SVG from the specified document content.
+func AcquireSVGFromData(data []byte) (*SVG, error) {
+	svg, err := newSVG(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	svgRegistryLock.Lock()
+	svgRegistry[svg.key] = &svgRef{svg: svg, count: 1}
+	svgRegistryLock.Unlock()
+	return svg, nil
+}
+
+// Size returns the intrinsic size of the SVG.
+func (svg *SVG) Size() geom.Size {
+	return svg.size
+}
+
+// Draw the SVG into the context, scaled to fill the bounds.
+func (svg *SVG) Draw(gc *Graphics, bounds geom.Rect) {
+	if svg.handle == nil || svg.size.Width <= 0 || svg.size.Height <= 0 {
+		return
+	}
+	gc.Save()
+	gc.Translate(bounds.X, bounds.Y)
+	gc.Scale(bounds.Width/svg.size.Width, bounds.Height/svg.size.Height)
+	C.rsvg_handle_render_cairo(svg.handle, gc.gc)
+	gc.Restore()
+}
+
+// Raster returns a rasterized version of the SVG at the specified size, rounded up to whole
+// pixels. The most recently used rasters are cached, so callers must not release them. As a raster
+// may be released once enough other sizes have been requested, it should be used right away rather
+// than retained.
+func (svg *SVG) Raster(size geom.Size) *Image {
+	return svg.raster(rasterKey{width: int(math.Ceil(size.Width)), height: int(math.Ceil(size.Height))})
+}
+
+// DisabledRaster returns a rasterized version of the SVG at the specified size that is desaturated
+// and ghosted to represent a disabled state. The same caching rules as for Raster apply.
+func (svg *SVG) DisabledRaster(size geom.Size) *Image {
+	return svg.raster(rasterKey{width: int(math.Ceil(size.Width)), height: int(math.Ceil(size.Height)), disabled: true})
+}
+
+func (svg *SVG) raster(key rasterKey) *Image {
+	if key.width < 1 {
+		key.width = 1
+	}
+	if key.height < 1 {
+		key.height = 1
+	}
+	svg.rasterLock.Lock()
+	defer svg.rasterLock.Unlock()
+	if img := svg.cachedRaster(key); img != nil {
+		return img
+	}
+	var img *Image
+	if key.disabled {
+		enabledKey := key
+		enabledKey.disabled = false
+		base := svg.cachedRaster(enabledKey)
+		if base == nil {
+			base = svg.rasterize(key.width, key.height)
+			svg.cacheRaster(enabledKey, base)
+		}
+		img = base.AcquireDisabled()
+	} else {
+		img = svg.rasterize(key.width, key.height)
+	}
+	svg.cacheRaster(key, img)
+	return img
+}
+
+func (svg *SVG) cachedRaster(key rasterKey) *Image {
+	if elem, ok := svg.rasterCache[key]; ok {
+		svg.rasterOrder.MoveToFront(elem)
+		return elem.Value.(*raster).img
+	}
+	return nil
+}
+
+func (svg *SVG) cacheRaster(key rasterKey, img *Image) {
+	for svg.rasterOrder.Len() >= maxCachedRasters {
+		oldest := svg.rasterOrder.Back()
+		r := oldest.Value.(*raster)
+		svg.rasterOrder.Remove(oldest)
+		delete(svg.rasterCache, r.key)
+		r.img.Release()
+	}
+	svg.rasterCache[key] = svg.rasterOrder.PushFront(&raster{key: key, img: img})
+}
+
+func (svg *SVG) rasterize(width, height int) *Image {
+	img := NewImage(width, height)
+	gc := NewGraphics(img.NewCairoContext())
+	svg.Draw(gc, geom.Rect{Size: geom.Size{Width: float64(width), Height: float64(height)}})
+	gc.Dispose()
+	C.cairo_surface_flush(img.surface)
+	return img
+}
+
+// Release releases the SVG. If no other client is using the SVG, then it and its cached rasters
+// will be disposed of.
+func (svg *SVG) Release() {
+	svgRegistryLock.Lock()
+	defer svgRegistryLock.Unlock()
+	if ref, ok := svgRegistry[svg.key]; ok {
+		ref.count--
+		if ref.count > 0 {
+			return
+		}
+		delete(svgRegistry, svg.key)
+	}
+	svg.rasterLock.Lock()
+	for elem := svg.rasterOrder.Front(); elem != nil; elem = elem.Next() {
+		elem.Value.(*raster).img.Release()
+	}
+	svg.rasterCache = make(map[rasterKey]*list.Element)
+	svg.rasterOrder.Init()
+	svg.rasterLock.Unlock()
+	if svg.handle != nil {
+		C.g_object_unref(C.gpointer(svg.handle))
+		svg.handle = nil
+	}
+}
+
+func (svg *SVG) String() string {
+	return fmt.Sprintf("SVG #%d", svg.ID())
+}
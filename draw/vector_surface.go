@@ -0,0 +1,75 @@
+package draw
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	// #include <cairo/cairo-pdf.h>
+	// #include <cairo/cairo-ps.h>
+	// #include <cairo/cairo-svg.h>
+	//
+	// extern cairo_status_t drawWriteToStream(void *closure, unsigned char *data, unsigned int length);
+	//
+	// static cairo_surface_t *newPDFSurface(unsigned long id, double width, double height) {
+	//     return cairo_pdf_surface_create_for_stream((cairo_write_func_t)drawWriteToStream, (void *)id, width, height);
+	// }
+	//
+	// static cairo_surface_t *newPSSurface(unsigned long id, double width, double height) {
+	//     return cairo_ps_surface_create_for_stream((cairo_write_func_t)drawWriteToStream, (void *)id, width, height);
+	// }
+	//
+	// static cairo_surface_t *newSVGSurface(unsigned long id, double width, double height) {
+	//     return cairo_svg_surface_create_for_stream((cairo_write_func_t)drawWriteToStream, (void *)id, width, height);
+	// }
+	"C"
+	"io"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// NewPDFSurface creates a new surface that produces a PDF document with pages of the specified size,
+// in points, which is written to 'w' as drawing proceeds. Finish() must be called to complete the
+// document.
+func NewPDFSurface(w io.Writer, size geom.Size) *Surface {
+	id := registerStream(w)
+	return &Surface{surface: C.newPDFSurface(C.ulong(id), C.double(size.Width), C.double(size.Height)), size: size, stream: id}
+}
+
+// NewPSSurface creates a new surface that produces a PostScript document with pages of the
+// specified size, in points, which is written to 'w' as drawing proceeds. Finish() must be called
+// to complete the document.
+func NewPSSurface(w io.Writer, size geom.Size) *Surface {
+	id := registerStream(w)
+	return &Surface{surface: C.newPSSurface(C.ulong(id), C.double(size.Width), C.double(size.Height)), size: size, stream: id}
+}
+
+// NewSVGSurface creates a new surface that produces an SVG document of the specified size, in
+// points, which is written to 'w' as drawing proceeds. Finish() must be called to complete the
+// document.
+func NewSVGSurface(w io.Writer, size geom.Size) *Surface {
+	id := registerStream(w)
+	return &Surface{surface: C.newSVGSurface(C.ulong(id), C.double(size.Width), C.double(size.Height)), size: size, stream: id}
+}
+
+// ShowPage emits the current page of a multi-page surface, such as one created by NewPDFSurface or
+// NewPSSurface, and starts a new, blank page.
+func (surface *Surface) ShowPage() {
+	C.cairo_surface_show_page(surface.surface)
+}
+
+// Finish completes any pending output for the surface. Drawing to the surface after this has been
+// called has no effect. Returns any error that occurred while writing.
+func (surface *Surface) Finish() error {
+	C.cairo_surface_finish(surface.surface)
+	var err error
+	if surface.stream != 0 {
+		err = unregisterStream(surface.stream)
+		surface.stream = 0
+	}
+	if err == nil {
+		if status := C.cairo_surface_status(surface.surface); status != C.CAIRO_STATUS_SUCCESS {
+			err = errs.New(C.GoString(C.cairo_status_to_string(status)))
+		}
+	}
+	return err
+}
@@ -40,6 +40,11 @@ const (
 	ClosedType
 	ValidateType
 	ModifiedType
+	PinchType
+	RotateType
+	PanType
+	TapType
+	SystemFontsChangedType
 	// UserType should be used as the base value for custom application
 	// events.
 	UserType = 10000
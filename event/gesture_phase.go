@@ -0,0 +1,31 @@
+package event
+
+import "fmt"
+
+// Possible GesturePhase values.
+const (
+	// GestureBegan indicates the gesture has just been recognized.
+	GestureBegan GesturePhase = iota
+	// GestureChanged indicates the gesture is continuing.
+	GestureChanged
+	// GestureEnded indicates the gesture has finished, either because the touches involved were
+	// lifted or because they no longer form the gesture.
+	GestureEnded
+)
+
+// GesturePhase describes where within a continuous gesture, such as a pinch, an event falls.
+type GesturePhase int
+
+// String implements the fmt.Stringer interface.
+func (p GesturePhase) String() string {
+	switch p {
+	case GestureBegan:
+		return "Began"
+	case GestureChanged:
+		return "Changed"
+	case GestureEnded:
+		return "Ended"
+	default:
+		return fmt.Sprintf("GesturePhase(%d)", int(p))
+	}
+}
@@ -8,12 +8,48 @@ import (
 	"github.com/richardwilkes/ui/keys"
 )
 
+// Possible WheelPhase values.
+const (
+	// WheelPhaseNone indicates the event came from a notched device, such as a
+	// traditional mouse wheel, which produces discrete steps.
+	WheelPhaseNone WheelPhase = iota
+	// WheelPhaseBegan indicates the first event of a scroll gesture from a
+	// precise device, such as a touchpad.
+	WheelPhaseBegan
+	// WheelPhaseChanged indicates a subsequent event of a scroll gesture from a
+	// precise device.
+	WheelPhaseChanged
+	// WheelPhaseEnded indicates the scroll gesture from a precise device has
+	// ended. The delta of such events is always zero.
+	WheelPhaseEnded
+)
+
+// WheelPhase describes where within a scroll gesture a MouseWheel event falls.
+type WheelPhase int
+
+// String implements the fmt.Stringer interface.
+func (p WheelPhase) String() string {
+	switch p {
+	case WheelPhaseNone:
+		return "None"
+	case WheelPhaseBegan:
+		return "Began"
+	case WheelPhaseChanged:
+		return "Changed"
+	case WheelPhaseEnded:
+		return "Ended"
+	default:
+		return fmt.Sprintf("WheelPhase(%d)", int(p))
+	}
+}
+
 // MouseWheel is generated when the mouse wheel is used over a widget.
 type MouseWheel struct {
 	target    Target
 	delta     geom.Point
 	where     geom.Point
 	modifiers keys.Modifiers
+	phase     WheelPhase
 	finished  bool
 }
 
@@ -24,6 +60,14 @@ func NewMouseWheel(target Target, delta geom.Point, where geom.Point, modifiers
 	return &MouseWheel{target: target, delta: delta, where: where, modifiers: modifiers}
 }
 
+// NewPreciseMouseWheel creates a new MouseWheel event from a precise device,
+// such as a touchpad. The parameters are the same as for NewMouseWheel, except
+// that 'delta' may be fractional and 'phase' indicates where within the scroll
+// gesture the event falls.
+func NewPreciseMouseWheel(target Target, delta geom.Point, where geom.Point, modifiers keys.Modifiers, phase WheelPhase) *MouseWheel {
+	return &MouseWheel{target: target, delta: delta, where: where, modifiers: modifiers, phase: phase}
+}
+
 // Type returns the event type ID.
 func (e *MouseWheel) Type() Type {
 	return MouseWheelType
@@ -64,10 +108,25 @@ func (e *MouseWheel) Modifiers() keys.Modifiers {
 	return e.modifiers
 }
 
+// Phase returns where within a scroll gesture this event falls. Events from
+// notched devices always return WheelPhaseNone.
+func (e *MouseWheel) Phase() WheelPhase {
+	return e.phase
+}
+
+// Precise returns true if this event came from a precise device, such as a
+// touchpad, rather than a notched mouse wheel.
+func (e *MouseWheel) Precise() bool {
+	return e.phase != WheelPhaseNone
+}
+
 // String implements the fmt.Stringer interface.
 func (e *MouseWheel) String() string {
 	var buffer bytes.Buffer
 	buffer.WriteString(fmt.Sprintf("MouseWheel[Delta: [%v], Where: [%v], Target: %v", e.delta, e.where, e.target))
+	if e.phase != WheelPhaseNone {
+		buffer.WriteString(fmt.Sprintf(", Phase: %v", e.phase))
+	}
 	modifiers := e.modifiers.String()
 	if modifiers != "" {
 		buffer.WriteString(", ")
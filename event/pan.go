@@ -0,0 +1,97 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+// Pan is generated when two touches move together in the same direction.
+type Pan struct {
+	target      Target
+	where       geom.Point
+	translation geom.Point
+	delta       geom.Point
+	phase       GesturePhase
+	modifiers   keys.Modifiers
+	finished    bool
+}
+
+// NewPan creates a new Pan event. 'target' is the widget the gesture started over. 'where' is the
+// location in the window of the center point between the touches. 'translation' is the distance
+// the center point has moved since the gesture began. 'delta' is the distance it has moved since
+// the previous event in the gesture. 'phase' is where within the gesture this event falls.
+// 'modifiers' are the keyboard modifiers keys that were down.
+func NewPan(target Target, where, translation, delta geom.Point, phase GesturePhase, modifiers keys.Modifiers) *Pan {
+	return &Pan{target: target, where: where, translation: translation, delta: delta, phase: phase, modifiers: modifiers}
+}
+
+// Type returns the event type ID.
+func (e *Pan) Type() Type {
+	return PanType
+}
+
+// Target the original target of the event.
+func (e *Pan) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Pan) Cascade() bool {
+	return true
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Pan) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Pan) Finish() {
+	e.finished = true
+}
+
+// Where returns the location in the window of the center point between the touches.
+func (e *Pan) Where() geom.Point {
+	return e.where
+}
+
+// Translation returns the distance the center point between the touches has moved since the
+// gesture began.
+func (e *Pan) Translation() geom.Point {
+	return e.translation
+}
+
+// Delta returns the distance the center point between the touches has moved since the previous
+// event in the gesture.
+func (e *Pan) Delta() geom.Point {
+	return e.delta
+}
+
+// Phase returns where within the gesture this event falls.
+func (e *Pan) Phase() GesturePhase {
+	return e.phase
+}
+
+// Modifiers returns the key modifiers that were down.
+func (e *Pan) Modifiers() keys.Modifiers {
+	return e.modifiers
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Pan) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Pan[Where: [%v], Translation: [%v], Phase: %v, Target: %v", e.where, e.translation, e.phase, e.target))
+	modifiers := e.modifiers.String()
+	if modifiers != "" {
+		buffer.WriteString(", ")
+		buffer.WriteString(modifiers)
+	}
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
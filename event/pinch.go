@@ -0,0 +1,98 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+// Pinch is generated when two touches move towards or away from each other, typically to zoom.
+type Pinch struct {
+	target     Target
+	where      geom.Point
+	scale      float64
+	deltaScale float64
+	phase      GesturePhase
+	modifiers  keys.Modifiers
+	finished   bool
+}
+
+// NewPinch creates a new Pinch event. 'target' is the widget the gesture started over. 'where' is
+// the location in the window of the center point between the touches. 'scale' is the ratio of the
+// current distance between the touches to their distance when the gesture began. 'deltaScale' is
+// the ratio of the current scale to the scale of the previous event in the gesture. 'phase' is
+// where within the gesture this event falls. 'modifiers' are the keyboard modifiers keys that were
+// down.
+func NewPinch(target Target, where geom.Point, scale, deltaScale float64, phase GesturePhase, modifiers keys.Modifiers) *Pinch {
+	return &Pinch{target: target, where: where, scale: scale, deltaScale: deltaScale, phase: phase, modifiers: modifiers}
+}
+
+// Type returns the event type ID.
+func (e *Pinch) Type() Type {
+	return PinchType
+}
+
+// Target the original target of the event.
+func (e *Pinch) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Pinch) Cascade() bool {
+	return true
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Pinch) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Pinch) Finish() {
+	e.finished = true
+}
+
+// Where returns the location in the window of the center point between the touches.
+func (e *Pinch) Where() geom.Point {
+	return e.where
+}
+
+// Scale returns the ratio of the current distance between the touches to their distance when the
+// gesture began.
+func (e *Pinch) Scale() float64 {
+	return e.scale
+}
+
+// DeltaScale returns the ratio of the current scale to the scale of the previous event in the
+// gesture.
+func (e *Pinch) DeltaScale() float64 {
+	return e.deltaScale
+}
+
+// Phase returns where within the gesture this event falls.
+func (e *Pinch) Phase() GesturePhase {
+	return e.phase
+}
+
+// Modifiers returns the key modifiers that were down.
+func (e *Pinch) Modifiers() keys.Modifiers {
+	return e.modifiers
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Pinch) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Pinch[Where: [%v], Scale: %v, Phase: %v, Target: %v", e.where, e.scale, e.phase, e.target))
+	modifiers := e.modifiers.String()
+	if modifiers != "" {
+		buffer.WriteString(", ")
+		buffer.WriteString(modifiers)
+	}
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -0,0 +1,95 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+// Rotate is generated when two touches rotate around the point between them.
+type Rotate struct {
+	target     Target
+	where      geom.Point
+	angle      float64
+	deltaAngle float64
+	phase      GesturePhase
+	modifiers  keys.Modifiers
+	finished   bool
+}
+
+// NewRotate creates a new Rotate event. 'target' is the widget the gesture started over. 'where'
+// is the location in the window of the center point between the touches. 'angle' is the rotation,
+// in radians, since the gesture began, with positive values being clockwise. 'deltaAngle' is the
+// rotation since the previous event in the gesture. 'phase' is where within the gesture this event
+// falls. 'modifiers' are the keyboard modifiers keys that were down.
+func NewRotate(target Target, where geom.Point, angle, deltaAngle float64, phase GesturePhase, modifiers keys.Modifiers) *Rotate {
+	return &Rotate{target: target, where: where, angle: angle, deltaAngle: deltaAngle, phase: phase, modifiers: modifiers}
+}
+
+// Type returns the event type ID.
+func (e *Rotate) Type() Type {
+	return RotateType
+}
+
+// Target the original target of the event.
+func (e *Rotate) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Rotate) Cascade() bool {
+	return true
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Rotate) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Rotate) Finish() {
+	e.finished = true
+}
+
+// Where returns the location in the window of the center point between the touches.
+func (e *Rotate) Where() geom.Point {
+	return e.where
+}
+
+// Angle returns the rotation, in radians, since the gesture began. Positive values are clockwise.
+func (e *Rotate) Angle() float64 {
+	return e.angle
+}
+
+// DeltaAngle returns the rotation, in radians, since the previous event in the gesture.
+func (e *Rotate) DeltaAngle() float64 {
+	return e.deltaAngle
+}
+
+// Phase returns where within the gesture this event falls.
+func (e *Rotate) Phase() GesturePhase {
+	return e.phase
+}
+
+// Modifiers returns the key modifiers that were down.
+func (e *Rotate) Modifiers() keys.Modifiers {
+	return e.modifiers
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Rotate) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Rotate[Where: [%v], Angle: %v, Phase: %v, Target: %v", e.where, e.angle, e.phase, e.target))
+	modifiers := e.modifiers.String()
+	if modifiers != "" {
+		buffer.WriteString(", ")
+		buffer.WriteString(modifiers)
+	}
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
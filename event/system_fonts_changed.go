@@ -0,0 +1,60 @@
+package event
+
+import (
+	"bytes"
+)
+
+// SystemFontsChanged is generated after the desktop's font settings have changed and the standard
+// fonts, such as font.System, have been updated to reflect them. It is sent to the global target
+// and then to each widget within an open window.
+type SystemFontsChanged struct {
+	target   Target
+	finished bool
+}
+
+// NewSystemFontsChanged creates a new SystemFontsChanged event. 'target' is the widget whose fonts
+// may need updating.
+func NewSystemFontsChanged(target Target) *SystemFontsChanged {
+	return &SystemFontsChanged{target: target}
+}
+
+// SendSystemFontsChanged sends a new SystemFontsChanged event to the global target.
+func SendSystemFontsChanged() {
+	Dispatch(NewSystemFontsChanged(GlobalTarget()))
+}
+
+// Type returns the event type ID.
+func (e *SystemFontsChanged) Type() Type {
+	return SystemFontsChangedType
+}
+
+// Target the original target of the event.
+func (e *SystemFontsChanged) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *SystemFontsChanged) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *SystemFontsChanged) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *SystemFontsChanged) Finish() {
+	e.finished = true
+}
+
+// String implements the fmt.Stringer interface.
+func (e *SystemFontsChanged) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("SystemFontsChanged[")
+	if e.finished {
+		buffer.WriteString("Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
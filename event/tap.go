@@ -0,0 +1,82 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+// Tap is generated when several touches are briefly placed and then lifted without moving.
+// Single-finger taps are delivered as mouse clicks instead.
+type Tap struct {
+	target    Target
+	where     geom.Point
+	touches   int
+	modifiers keys.Modifiers
+	finished  bool
+}
+
+// NewTap creates a new Tap event. 'target' is the widget the touches were over. 'where' is the
+// location in the window of the center point between the touches. 'touches' is the number of
+// touches involved. 'modifiers' are the keyboard modifiers keys that were down.
+func NewTap(target Target, where geom.Point, touches int, modifiers keys.Modifiers) *Tap {
+	return &Tap{target: target, where: where, touches: touches, modifiers: modifiers}
+}
+
+// Type returns the event type ID.
+func (e *Tap) Type() Type {
+	return TapType
+}
+
+// Target the original target of the event.
+func (e *Tap) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Tap) Cascade() bool {
+	return true
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Tap) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Tap) Finish() {
+	e.finished = true
+}
+
+// Where returns the location in the window of the center point between the touches.
+func (e *Tap) Where() geom.Point {
+	return e.where
+}
+
+// Touches returns the number of touches involved.
+func (e *Tap) Touches() int {
+	return e.touches
+}
+
+// Modifiers returns the key modifiers that were down.
+func (e *Tap) Modifiers() keys.Modifiers {
+	return e.modifiers
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Tap) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Tap[Where: [%v], Touches: %d, Target: %v", e.where, e.touches, e.target))
+	modifiers := e.modifiers.String()
+	if modifiers != "" {
+		buffer.WriteString(", ")
+		buffer.WriteString(modifiers)
+	}
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -0,0 +1,14 @@
+package find
+
+// Findable defines the methods required of objects that can be searched with the Find bar and the
+// Find menu items.
+type Findable interface {
+	// FindNext selects the next match after the current selection, wrapping around to the start
+	// if necessary, and scrolls it into view. Returns false if there are no matches.
+	FindNext(matcher *Matcher) bool
+	// FindPrevious selects the previous match before the current selection, wrapping around to
+	// the end if necessary, and scrolls it into view. Returns false if there are no matches.
+	FindPrevious(matcher *Matcher) bool
+	// SetFindHighlight highlights all matches, or removes the highlighting if 'matcher' is nil.
+	SetFindHighlight(matcher *Matcher)
+}
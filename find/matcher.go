@@ -0,0 +1,161 @@
+package find
+
+import (
+	"regexp"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// Options describes what to search for.
+type Options struct {
+	// Text holds the text to search for. When Regex is true, it holds a regular expression using
+	// the syntax accepted by the regexp package.
+	Text string
+	// MatchCase is true if upper and lower case letters must match exactly.
+	MatchCase bool
+	// WholeWord is true if matches must not begin or end in the middle of a word. A match that
+	// begins or ends with a character that isn't part of a word, such as punctuation, is only
+	// constrained on its other side.
+	WholeWord bool
+	// Regex is true if Text is a regular expression.
+	Regex bool
+}
+
+// Range holds the rune indexes of a match, from Start up to, but not including, End.
+type Range struct {
+	Start int
+	End   int
+}
+
+// Matcher locates the matches for a set of Options within text.
+type Matcher struct {
+	options Options
+	re      *regexp.Regexp
+}
+
+var (
+	lastLock    sync.Mutex
+	lastOptions Options
+)
+
+// LastOptions returns the options most recently used for searching, which are shared by all
+// windows of the application.
+func LastOptions() Options {
+	lastLock.Lock()
+	defer lastLock.Unlock()
+	return lastOptions
+}
+
+// SetLastOptions sets the options most recently used for searching.
+func SetLastOptions(options Options) {
+	lastLock.Lock()
+	lastOptions = options
+	lastLock.Unlock()
+}
+
+// NewMatcher creates a new Matcher for the options. Returns an error if Regex is true and the text
+// isn't a valid regular expression, or if the text is empty.
+func NewMatcher(options Options) (*Matcher, error) {
+	if options.Text == "" {
+		return nil, errs.New("nothing to search for")
+	}
+	pattern := options.Text
+	if !options.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !options.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errs.NewWithCause("invalid regular expression", err)
+	}
+	return &Matcher{options: options, re: re}, nil
+}
+
+// Options returns the options the Matcher was created with.
+func (m *Matcher) Options() Options {
+	return m.options
+}
+
+// Matches returns the ranges of all matches within 'text', in order. Matches don't overlap and
+// are never empty.
+func (m *Matcher) Matches(text string) []Range {
+	var ranges []Range
+	runeIndex := 0
+	byteIndex := 0
+	for _, one := range m.re.FindAllStringIndex(text, -1) {
+		if one[0] == one[1] || (m.options.WholeWord && !isWholeWord(text, one[0], one[1])) {
+			continue
+		}
+		runeIndex += utf8.RuneCountInString(text[byteIndex:one[0]])
+		start := runeIndex
+		runeIndex += utf8.RuneCountInString(text[one[0]:one[1]])
+		byteIndex = one[1]
+		ranges = append(ranges, Range{Start: start, End: runeIndex})
+	}
+	return ranges
+}
+
+// isWholeWord returns true if the match between the byte indexes 'start' and 'end' doesn't begin or
+// end in the middle of a word. A boundary only needs to be checked where the match itself begins or
+// ends with a word character.
+func isWholeWord(text string, start, end int) bool {
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		if isWordRune(before) && isWordRune(first) {
+			return false
+		}
+	}
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(last) && isWordRune(after) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(ch rune) bool {
+	return ch == '_' || unicode.IsLetter(ch) || unicode.IsDigit(ch) || unicode.Is(unicode.Mn, ch)
+}
+
+// Contains returns true if 'text' contains at least one match.
+func (m *Matcher) Contains(text string) bool {
+	return len(m.Matches(text)) > 0
+}
+
+// Next returns the first match within 'text' that starts at or after the rune index 'from',
+// wrapping around to the first match in the text if there are none after it.
+func (m *Matcher) Next(text string, from int) (match Range, found bool) {
+	ranges := m.Matches(text)
+	for _, one := range ranges {
+		if one.Start >= from {
+			return one, true
+		}
+	}
+	if len(ranges) > 0 {
+		return ranges[0], true
+	}
+	return Range{}, false
+}
+
+// Previous returns the last match within 'text' that ends at or before the rune index 'before',
+// wrapping around to the last match in the text if there are none before it.
+func (m *Matcher) Previous(text string, before int) (match Range, found bool) {
+	ranges := m.Matches(text)
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if ranges[i].End <= before {
+			return ranges[i], true
+		}
+	}
+	if len(ranges) > 0 {
+		return ranges[len(ranges)-1], true
+	}
+	return Range{}, false
+}
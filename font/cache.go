@@ -0,0 +1,106 @@
+package font
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+const (
+	// measureCacheSize is the maximum number of measured strings remembered for each font
+	// description.
+	measureCacheSize = 1024
+	// fontCacheSize is the maximum number of font descriptions whose caches are retained, so that
+	// fonts whose size is animated, for example, don't consume memory without limit.
+	fontCacheSize = 64
+)
+
+var (
+	cacheLock  sync.Mutex
+	caches     = make(map[string]*list.Element)
+	cacheOrder = list.New()
+	// cacheGeneration is incremented each time the caches are reset, so that fonts holding on to a
+	// cache from before then know to fetch a new one. Must be accessed atomically.
+	cacheGeneration uint64
+)
+
+// fontCache holds the metrics and measurements for a font description. It is shared by all Font
+// objects with the same description.
+type fontCache struct {
+	description   string
+	generation    uint64
+	lock          sync.Mutex
+	metricsLoaded bool
+	leading       float64
+	ascent        float64
+	descent       float64
+	monospaced    bool
+	measured      map[string]*list.Element
+	order         *list.List
+}
+
+type measurement struct {
+	text string
+	size geom.Size
+}
+
+// cacheFor returns the cache for the font description, creating it if necessary. Once the number of
+// caches reaches its limit, the least recently requested one is no longer shared, although fonts
+// already using it may continue to do so.
+func cacheFor(description string) *fontCache {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if elem, ok := caches[description]; ok {
+		cacheOrder.MoveToFront(elem)
+		return elem.Value.(*fontCache)
+	}
+	if cacheOrder.Len() >= fontCacheSize {
+		oldest := cacheOrder.Back()
+		delete(caches, oldest.Value.(*fontCache).description)
+		cacheOrder.Remove(oldest)
+	}
+	cache := &fontCache{description: description, generation: atomic.LoadUint64(&cacheGeneration), measured: make(map[string]*list.Element), order: list.New()}
+	caches[description] = cacheOrder.PushFront(cache)
+	return cache
+}
+
+// resetCaches discards all metrics and measurements, so that they are reloaded the next time they
+// are needed. This is necessary when the set of available fonts changes.
+func resetCaches() {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	caches = make(map[string]*list.Element)
+	cacheOrder.Init()
+	atomic.AddUint64(&cacheGeneration, 1)
+}
+
+// lookup returns the size of a previously measured string, marking it as the most recently used.
+func (c *fontCache) lookup(text string) (geom.Size, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.measured[text]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*measurement).size, true
+	}
+	return geom.Size{}, false
+}
+
+// remember the size of a measured string, discarding the least recently used measurement if the
+// cache is full.
+func (c *fontCache) remember(text string, size geom.Size) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.measured[text]; ok {
+		elem.Value.(*measurement).size = size
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.order.Len() >= measureCacheSize {
+		oldest := c.order.Back()
+		delete(c.measured, oldest.Value.(*measurement).text)
+		c.order.Remove(oldest)
+	}
+	c.measured[text] = c.order.PushFront(&measurement{text: text, size: size})
+}
@@ -10,23 +10,40 @@ import (
 )
 
 var (
-	familiesOnce sync.Once
+	familiesLock sync.Mutex
 	familiesAll  []*Family
 	familiesMono []*Family
 )
 
-// Families returns the installed font families.
+// Families returns the installed font families, including those registered with RegisterFile or
+// RegisterFromFileSystem.
 func Families() []*Family {
-	familiesOnce.Do(initFamilyLists)
+	familiesLock.Lock()
+	defer familiesLock.Unlock()
+	if familiesAll == nil {
+		initFamilyLists()
+	}
 	return familiesAll
 }
 
 // MonospacedFamilies returns the installed font families that are monospaced.
 func MonospacedFamilies() []*Family {
-	familiesOnce.Do(initFamilyLists)
+	familiesLock.Lock()
+	defer familiesLock.Unlock()
+	if familiesAll == nil {
+		initFamilyLists()
+	}
 	return familiesMono
 }
 
+// resetFamilyLists causes the family lists to be rebuilt the next time they are requested.
+func resetFamilyLists() {
+	familiesLock.Lock()
+	familiesAll = nil
+	familiesMono = nil
+	familiesLock.Unlock()
+}
+
 func initFamilyLists() {
 	var list **C.PangoFontFamily
 	var count C.int
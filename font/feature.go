@@ -0,0 +1,169 @@
+package font
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Commonly used OpenType feature tags. See the OpenType feature registry for the complete list.
+const (
+	FeatureKerning                = "kern"
+	FeatureStandardLigatures      = "liga"
+	FeatureContextualLigatures    = "clig"
+	FeatureDiscretionaryLigatures = "dlig"
+	FeatureSmallCaps              = "smcp"
+	FeatureCapitalsToSmallCaps    = "c2sc"
+	FeatureTabularNumerals        = "tnum"
+	FeatureProportionalNumerals   = "pnum"
+	FeatureLiningNumerals         = "lnum"
+	FeatureOldstyleNumerals       = "onum"
+	FeatureFractions              = "frac"
+	FeatureSlashedZero            = "zero"
+)
+
+// Registered variation axis tags. Fonts may also define their own axes.
+const (
+	AxisWeight      = "wght"
+	AxisWidth       = "wdth"
+	AxisSlant       = "slnt"
+	AxisItalic      = "ital"
+	AxisOpticalSize = "opsz"
+)
+
+// Features returns the OpenType features that have been explicitly set, keyed by their tag.
+// Features that haven't been set use the font's defaults.
+func (d *Font) Features() map[string]int {
+	features := make(map[string]int, len(d.features))
+	for tag, value := range d.features {
+		features[tag] = value
+	}
+	return features
+}
+
+// Feature returns the value of the OpenType feature with the specified tag, and whether it has
+// been explicitly set.
+func (d *Font) Feature(tag string) (value int, ok bool) {
+	value, ok = d.features[tag]
+	return value, ok
+}
+
+// SetFeature sets the value of the OpenType feature with the specified tag. A value of 0 disables
+// the feature and 1 enables it. Some features, such as stylistic alternates, accept larger values
+// to select among several alternatives.
+func (d *Font) SetFeature(tag string, value int) {
+	if d.features == nil {
+		d.features = make(map[string]int)
+	}
+	d.features[tag] = value
+	d.changed()
+}
+
+// ClearFeature removes the setting for the OpenType feature with the specified tag, restoring the
+// font's default.
+func (d *Font) ClearFeature(tag string) {
+	if _, ok := d.features[tag]; ok {
+		delete(d.features, tag)
+		d.changed()
+	}
+}
+
+// FeatureSettings returns the features that have been explicitly set, in the "tag=value" format
+// understood by HarfBuzz and Pango, separated by commas. Returns an empty string if no features
+// have been set.
+func (d *Font) FeatureSettings() string {
+	settings := make([]string, 0, len(d.features))
+	for tag, value := range d.features {
+		settings = append(settings, fmt.Sprintf("%s=%d", tag, value))
+	}
+	sort.Strings(settings)
+	return strings.Join(settings, ",")
+}
+
+// PangoFeaturesAttribute returns a newly created PangoAttribute applying the features to the
+// entire text of a layout, or nil if no features have been set.
+func (d *Font) PangoFeaturesAttribute() unsafe.Pointer {
+	settings := d.FeatureSettings()
+	if settings == "" {
+		return nil
+	}
+	cstr := C.CString(settings)
+	defer C.g_free(C.gpointer(cstr))
+	return unsafe.Pointer(C.pango_attr_font_features_new(cstr))
+}
+
+// PangoAttrList returns a newly created PangoAttrList containing the attributes needed to draw
+// text with this font, or nil if none are needed. The caller is responsible for releasing it with
+// pango_attr_list_unref().
+func (d *Font) PangoAttrList() unsafe.Pointer {
+	attr := d.PangoFeaturesAttribute()
+	if attr == nil {
+		return nil
+	}
+	list := C.pango_attr_list_new()
+	C.pango_attr_list_insert(list, (*C.PangoAttribute)(attr))
+	return unsafe.Pointer(list)
+}
+
+// Variations returns the variation axis settings, keyed by their tag. Axes that haven't been set
+// use the font's defaults, which may be derived from the weight, stretch and slant.
+func (d *Font) Variations() map[string]float64 {
+	variations := make(map[string]float64)
+	if cstr := C.pango_font_description_get_variations(d.pfd); cstr != nil {
+		for _, one := range strings.Split(C.GoString(cstr), ",") {
+			parts := strings.SplitN(strings.TrimSpace(one), "=", 2)
+			if len(parts) == 2 {
+				if value, err := strconv.ParseFloat(parts[1], 64); err == nil {
+					variations[parts[0]] = value
+				}
+			}
+		}
+	}
+	return variations
+}
+
+// Variation returns the value of the variation axis with the specified tag, and whether it has
+// been explicitly set.
+func (d *Font) Variation(axis string) (value float64, ok bool) {
+	value, ok = d.Variations()[axis]
+	return value, ok
+}
+
+// SetVariation sets the value of the variation axis with the specified tag. Values outside of the
+// range supported by the font are clamped. Fonts without the axis ignore it.
+func (d *Font) SetVariation(axis string, value float64) {
+	variations := d.Variations()
+	variations[axis] = value
+	d.setVariations(variations)
+}
+
+// ClearVariation removes the setting for the variation axis with the specified tag, restoring the
+// font's default.
+func (d *Font) ClearVariation(axis string) {
+	variations := d.Variations()
+	if _, ok := variations[axis]; ok {
+		delete(variations, axis)
+		d.setVariations(variations)
+	}
+}
+
+func (d *Font) setVariations(variations map[string]float64) {
+	if len(variations) == 0 {
+		C.pango_font_description_set_variations(d.pfd, nil)
+	} else {
+		settings := make([]string, 0, len(variations))
+		for axis, value := range variations {
+			settings = append(settings, axis+"="+strconv.FormatFloat(value, 'f', -1, 64))
+		}
+		sort.Strings(settings)
+		cstr := C.CString(strings.Join(settings, ","))
+		C.pango_font_description_set_variations(d.pfd, cstr)
+		C.g_free(C.gpointer(cstr))
+	}
+	d.changed()
+}
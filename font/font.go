@@ -4,6 +4,8 @@ import (
 	// #cgo pkg-config: pangocairo
 	// #include <pango/pangocairo.h>
 	"C"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
@@ -15,23 +17,75 @@ const (
 )
 
 var (
-	surface = C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, 8, 8)
-	context = C.pango_font_map_create_context(C.pango_cairo_font_map_get_default())
+	// The shared context and layout used for loading metrics and measuring text. Pango objects
+	// aren't thread-safe, so access to them must be guarded by the lock.
+	layoutLock sync.Mutex
+	context    = C.pango_font_map_create_context(C.pango_cairo_font_map_get_default())
+	layout     = C.pango_layout_new(context)
+	// A cairo context for a small image surface, which contexts used to lay out text before it is
+	// drawn are set up from, so that they start out with the font options, such as hinted metrics,
+	// used when drawing. Guarded by the layout lock.
+	scratch *C.cairo_t
+	// The font rendering options, such as antialiasing and hinting, to use instead of those of the
+	// target surface. May be nil.
+	fontOptions *C.cairo_font_options_t
 )
 
 // Font represents a font.
 type Font struct {
-	pfd           *C.PangoFontDescription
-	leading       float64
-	ascent        float64
-	descent       float64
-	monospaced    uint8
-	metricsLoaded bool
+	pfd      *C.PangoFontDescription
+	features map[string]int
+	cache    *fontCache
+	revision uint64
 }
 
 func init() {
 	// Tell Pango we want our typographic points to be 1/72 of an inch.
 	C.pango_cairo_font_map_set_resolution((*C.PangoCairoFontMap)(C.pango_cairo_font_map_get_default()), 72)
+	surface := C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, 1, 1)
+	scratch = C.cairo_create(surface)
+	C.cairo_surface_destroy(surface)
+	// Measure with the same font options, such as hinted metrics, as drawing uses.
+	C.pango_cairo_update_context(scratch, context)
+}
+
+// NewPangoContext creates a PangoContext for laying out text that may be measured before it is
+// drawn. It starts out set up as if for drawing to an image surface without any transformation.
+// Release it with g_object_unref() when done.
+func NewPangoContext() unsafe.Pointer {
+	pangoContext := C.pango_font_map_create_context(C.pango_cairo_font_map_get_default())
+	layoutLock.Lock()
+	C.pango_cairo_update_context(scratch, pangoContext)
+	layoutLock.Unlock()
+	ConfigurePangoContext(unsafe.Pointer(pangoContext))
+	return unsafe.Pointer(pangoContext)
+}
+
+// ConfigurePangoContext applies the font rendering options configured for the desktop, such as
+// antialiasing and hinting, to a PangoContext used for drawing or measuring text.
+func ConfigurePangoContext(pangoContext unsafe.Pointer) {
+	if fontOptions != nil {
+		C.pango_cairo_context_set_font_options((*C.PangoContext)(pangoContext), fontOptions)
+	}
+}
+
+// setPangoFontOptions replaces the font rendering options, taking ownership of 'options', and
+// discards any metrics and measurements made with the previous ones.
+func setPangoFontOptions(options *C.cairo_font_options_t) {
+	layoutLock.Lock()
+	if fontOptions != nil {
+		C.cairo_font_options_destroy(fontOptions)
+	}
+	fontOptions = options
+	if options == nil {
+		options = C.cairo_font_options_create()
+		C.pango_cairo_context_set_font_options(context, options)
+		C.cairo_font_options_destroy(options)
+	} else {
+		C.pango_cairo_context_set_font_options(context, options)
+	}
+	layoutLock.Unlock()
+	resetCaches()
 }
 
 // NewFont creates a font from a string.
@@ -46,6 +100,7 @@ func (d *Font) Copy() *Font {
 	desc := &Font{}
 	*desc = *d
 	desc.pfd = C.pango_font_description_copy(d.pfd)
+	desc.features = d.Features()
 	return desc
 }
 
@@ -55,6 +110,19 @@ func (d *Font) Dispose() {
 	d.pfd = nil
 }
 
+// Revision returns a value that changes each time the font is modified, whether through one of its
+// setters or by an update to the desktop's font settings. Objects that retain a copy of the font's
+// description, such as text layouts, can compare it to know when to pick up the changes.
+func (d *Font) Revision() uint64 {
+	return d.revision
+}
+
+// changed discards the cached metrics and bumps the revision.
+func (d *Font) changed() {
+	d.cache = nil
+	d.revision++
+}
+
 // PangoFontDescription returns the pointer to the underlying Pango font description.
 func (d *Font) PangoFontDescription() unsafe.Pointer {
 	return unsafe.Pointer(d.pfd)
@@ -92,7 +160,7 @@ func (d *Font) SetFamily(family string) {
 	cstr := C.CString(family)
 	C.pango_font_description_set_family(d.pfd, cstr)
 	C.g_free(C.gpointer(cstr))
-	d.metricsLoaded = false
+	d.changed()
 }
 
 // Slant returns the font slant.
@@ -103,7 +171,7 @@ func (d *Font) Slant() Slant {
 // SetSlant sets the font slant.
 func (d *Font) SetSlant(slant Slant) {
 	C.pango_font_description_set_style(d.pfd, C.PangoStyle(slant))
-	d.metricsLoaded = false
+	d.changed()
 }
 
 // Capitalization returns the font capitalization.
@@ -114,7 +182,7 @@ func (d *Font) Capitalization() Capitalization {
 // SetCapitalization sets the font capitalization.
 func (d *Font) SetCapitalization(capitalization Capitalization) {
 	C.pango_font_description_set_variant(d.pfd, C.PangoVariant(capitalization))
-	d.metricsLoaded = false
+	d.changed()
 }
 
 // Weight returns the font weight.
@@ -125,7 +193,7 @@ func (d *Font) Weight() Weight {
 // SetWeight sets the font weight.
 func (d *Font) SetWeight(weight Weight) {
 	C.pango_font_description_set_weight(d.pfd, C.PangoWeight(weight))
-	d.metricsLoaded = false
+	d.changed()
 }
 
 // Stretch returns the font stretch.
@@ -136,7 +204,7 @@ func (d *Font) Stretch() Stretch {
 // SetStretch sets the font stretch.
 func (d *Font) SetStretch(stretch Stretch) {
 	C.pango_font_description_set_stretch(d.pfd, C.PangoStretch(stretch))
-	d.metricsLoaded = false
+	d.changed()
 }
 
 // Size the size of the font, in points.
@@ -147,10 +215,11 @@ func (d *Font) Size() float64 {
 // SetSize sets the size of the font, in points.
 func (d *Font) SetSize(size float64) {
 	C.pango_font_description_set_size(d.pfd, C.gint(size*PangoScale))
-	d.metricsLoaded = false
+	d.changed()
 }
 
-// String returns a string that can be used with NewFont.
+// String returns a string that can be used with NewFont. Variation axis settings are included, but
+// OpenType feature settings are not.
 func (d *Font) String() string {
 	cstr := C.pango_font_description_to_string(d.pfd)
 	defer C.g_free(C.gpointer(cstr))
@@ -159,103 +228,120 @@ func (d *Font) String() string {
 
 // Monospaced returns true if this font has a fixed width.
 func (d *Font) Monospaced() bool {
-	d.loadMetrics()
-	return d.monospaced == 1
+	return d.loadMetrics().monospaced
 }
 
 // Leading returns the amount of space before the ascent.
 func (d *Font) Leading() float64 {
-	d.loadMetrics()
-	return d.leading
+	return d.loadMetrics().leading
 }
 
 // Ascent returns the amount of space used from the baseline to the top of the tallest character.
 func (d *Font) Ascent() float64 {
-	d.loadMetrics()
-	return d.ascent
+	return d.loadMetrics().ascent
 }
 
 // Descent returns the amount of space used from the baseline to the bottom.
 func (d *Font) Descent() float64 {
-	d.loadMetrics()
-	return d.descent
+	return d.loadMetrics().descent
 }
 
 // Height returns the overall height of the font, effectively Leading() + Ascent() + Descent().
 func (d *Font) Height() float64 {
-	d.loadMetrics()
-	return d.leading + d.ascent + d.descent
+	cache := d.loadMetrics()
+	return cache.leading + cache.ascent + cache.descent
 }
 
-func (d *Font) loadMetrics() {
-	if !d.metricsLoaded {
-		d.metricsLoaded = true
+// loadMetrics returns the cache for this font's description, loading its metrics if they haven't
+// been already.
+func (d *Font) loadMetrics() *fontCache {
+	if d.cache == nil || d.cache.generation != atomic.LoadUint64(&cacheGeneration) {
+		d.cache = cacheFor(d.String() + "|" + d.FeatureSettings())
+	}
+	cache := d.cache
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if !cache.metricsLoaded {
+		cache.metricsLoaded = true
+		layoutLock.Lock()
 		if f := C.pango_font_map_load_font(C.pango_cairo_font_map_get_default(), context, d.pfd); f != nil {
 			metrics := C.pango_font_get_metrics(f, C.pango_language_get_default())
-			d.ascent = float64(C.pango_font_metrics_get_ascent(metrics)) / PangoScale
-			d.descent = float64(C.pango_font_metrics_get_descent(metrics)) / PangoScale
-			d.leading = d.descent / 2
+			cache.ascent = float64(C.pango_font_metrics_get_ascent(metrics)) / PangoScale
+			cache.descent = float64(C.pango_font_metrics_get_descent(metrics)) / PangoScale
+			cache.leading = cache.descent / 2
 			C.pango_font_metrics_unref(metrics)
-		} else {
-			d.leading = 0
-			d.ascent = 0
-			d.descent = 0
+			C.g_object_unref(C.gpointer(f))
 		}
-		// The monospaced attribute only needs to ever be determined once.
-		if d.monospaced == 0 {
-			d.monospaced = 2
-			for _, family := range MonospacedFamilies() {
-				if family.Name() == d.Family() {
-					d.monospaced = 1
-					break
-				}
+		layoutLock.Unlock()
+		family := d.Family()
+		for _, one := range MonospacedFamilies() {
+			if one.Name() == family {
+				cache.monospaced = true
+				break
 			}
 		}
 	}
+	return cache
 }
 
 // Measure the string rendered with this font. If you want to account for transformations in a
-// graphics context, you must use the Measure method on the Graphics object instead.
+// graphics context, you must use the Measure method on the Graphics object instead. Measurements
+// are cached, so repeatedly measuring the same strings is inexpensive.
 func (d *Font) Measure(text string) geom.Size {
-	layout, gc := d.createLayout(text)
+	cache := d.loadMetrics()
+	if size, ok := cache.lookup(text); ok {
+		return size
+	}
+	size := d.measure(text)
+	cache.remember(text, size)
+	return size
+}
+
+// measure the string with Pango, bypassing the cache.
+func (d *Font) measure(text string) geom.Size {
+	leading := d.Leading()
+	layoutLock.Lock()
+	d.prepareLayout(text, leading)
 	var width, height C.int
 	C.pango_layout_get_size(layout, &width, &height)
-	d.destroyLayout(layout, gc)
-	return geom.Size{Width: float64(width) / PangoScale, Height: d.Leading() + float64(height)/PangoScale}
+	layoutLock.Unlock()
+	return geom.Size{Width: float64(width) / PangoScale, Height: leading + float64(height)/PangoScale}
 }
 
 // IndexForPosition returns the rune index within the string for the specified x-coordinate, where
 // 0 is the start of the string.
 func (d *Font) IndexForPosition(x float64, text string) int {
-	layout, gc := d.createLayout(text)
+	leading := d.Leading()
+	layoutLock.Lock()
+	defer layoutLock.Unlock()
+	d.prepareLayout(text, leading)
 	var index, trailing C.int
 	C.pango_layout_xy_to_index(layout, C.int(x*PangoScale), 0, &index, &trailing)
-	d.destroyLayout(layout, gc)
 	return int(index + trailing)
 }
 
 // PositionForIndex returns the x-coordinate where the specified rune index starts. The returned
 // coordinate assumes 0 is the start of the string.
 func (d *Font) PositionForIndex(index int, text string) float64 {
-	layout, gc := d.createLayout(text)
+	leading := d.Leading()
+	layoutLock.Lock()
+	defer layoutLock.Unlock()
+	d.prepareLayout(text, leading)
 	var x C.int
 	C.pango_layout_index_to_line_x(layout, C.int(index), 0, nil, &x)
-	d.destroyLayout(layout, gc)
 	return float64(x) / PangoScale
 }
 
-func (d *Font) createLayout(text string) (layout *C.PangoLayout, gc *C.cairo_t) {
-	gc = C.cairo_create(surface)
-	layout = C.pango_cairo_create_layout(gc)
+// prepareLayout sets up the shared layout for the text. The caller must hold the layout lock.
+func (d *Font) prepareLayout(text string, leading float64) {
 	C.pango_layout_set_font_description(layout, d.pfd)
-	C.pango_layout_set_spacing(layout, C.int(d.Leading()*PangoScale))
+	C.pango_layout_set_spacing(layout, C.int(leading*PangoScale))
 	cstr := C.CString(text)
 	C.pango_layout_set_text(layout, cstr, -1)
 	C.g_free(C.gpointer(cstr))
-	return layout, gc
-}
-
-func (d *Font) destroyLayout(layout *C.PangoLayout, gc *C.cairo_t) {
-	C.g_object_unref(C.gpointer(layout))
-	C.cairo_destroy(gc)
+	list := (*C.PangoAttrList)(d.PangoAttrList())
+	C.pango_layout_set_attributes(layout, list)
+	if list != nil {
+		C.pango_attr_list_unref(list)
+	}
 }
@@ -0,0 +1,90 @@
+package font
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchmarkStrings returns a large list of strings, such as the cells of a table, in which each
+// distinct string appears several times.
+func benchmarkStrings() []string {
+	const distinct = 250
+	const repeats = 8
+	list := make([]string, 0, distinct*repeats)
+	for i := 0; i < repeats; i++ {
+		for j := 0; j < distinct; j++ {
+			list = append(list, fmt.Sprintf("Row %d: The quick brown fox jumps over the lazy dog", j))
+		}
+	}
+	return list
+}
+
+func BenchmarkMeasureUncached(b *testing.B) {
+	f := NewFont("Sans 12")
+	defer f.Dispose()
+	list := benchmarkStrings()
+	f.Leading()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, one := range list {
+			f.measure(one)
+		}
+	}
+}
+
+func BenchmarkMeasureCached(b *testing.B) {
+	f := NewFont("Sans 12")
+	defer f.Dispose()
+	list := benchmarkStrings()
+	for _, one := range list {
+		f.Measure(one)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, one := range list {
+			f.Measure(one)
+		}
+	}
+}
+
+func BenchmarkMeasureCachedFromEmpty(b *testing.B) {
+	f := NewFont("Sans 12")
+	defer f.Dispose()
+	list := benchmarkStrings()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		resetCaches()
+		b.StartTimer()
+		for _, one := range list {
+			f.Measure(one)
+		}
+	}
+}
+
+func BenchmarkMeasureCachedParallel(b *testing.B) {
+	f := NewFont("Sans 12")
+	defer f.Dispose()
+	list := benchmarkStrings()
+	for _, one := range list {
+		f.Measure(one)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, one := range list {
+				f.Measure(one)
+			}
+		}
+	})
+}
+
+func TestMeasureCached(t *testing.T) {
+	f := NewFont("Sans 12")
+	defer f.Dispose()
+	for _, one := range benchmarkStrings()[:10] {
+		if cached, uncached := f.Measure(one), f.measure(one); cached != uncached {
+			t.Errorf("measurement of %q: cached %v, uncached %v", one, cached, uncached)
+		}
+	}
+}
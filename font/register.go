@@ -0,0 +1,124 @@
+package font
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/richardwilkes/toolbox/atexit"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+type fsKey struct {
+	fs   http.FileSystem
+	path string
+}
+
+var (
+	registeredLock    sync.Mutex
+	registered        = make(map[interface{}]bool)
+	registeredTempDir string
+)
+
+// RegisterFile makes the TrueType or OpenType font file at 'filePath' available to this process,
+// without installing it system-wide. Once registered, the families it contains are returned by
+// Families() and may be used with NewFont.
+func RegisterFile(filePath string) error {
+	registeredLock.Lock()
+	defer registeredLock.Unlock()
+	return registerFile(filePath)
+}
+
+// RegisterFromFileSystem makes the TrueType or OpenType font file at 'filePath' within 'fs'
+// available to this process, as RegisterFile does. If 'filePath' is a directory, all font files
+// within it and its subdirectories are registered.
+func RegisterFromFileSystem(fs http.FileSystem, filePath string) error {
+	registeredLock.Lock()
+	defer registeredLock.Unlock()
+	return registerFromFileSystem(fs, filePath)
+}
+
+func registerFile(filePath string) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if !registered[absPath] {
+		if err = platformRegisterFile(absPath); err != nil {
+			return err
+		}
+		registered[absPath] = true
+		resetFamilyLists()
+		resetCaches()
+	}
+	return nil
+}
+
+func registerFromFileSystem(fs http.FileSystem, filePath string) (err error) {
+	key := fsKey{fs: fs, path: filePath}
+	if registered[key] {
+		return nil
+	}
+	var file http.File
+	if file, err = fs.Open(filePath); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = errs.Wrap(cerr)
+		}
+	}()
+	var fi os.FileInfo
+	if fi, err = file.Stat(); err != nil {
+		return errs.Wrap(err)
+	}
+	if fi.IsDir() {
+		var entries []os.FileInfo
+		if entries, err = file.Readdir(-1); err != nil {
+			return errs.Wrap(err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || isFontFile(entry.Name()) {
+				if err = registerFromFileSystem(fs, path.Join(filePath, entry.Name())); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		// Font engines can only load fonts from real files, so copy the data out of the file system.
+		var data []byte
+		if data, err = ioutil.ReadAll(file); err != nil {
+			return errs.Wrap(err)
+		}
+		if registeredTempDir == "" {
+			if registeredTempDir, err = ioutil.TempDir("", "fonts"); err != nil {
+				return errs.Wrap(err)
+			}
+			dir := registeredTempDir
+			atexit.Register(func() { os.RemoveAll(dir) })
+		}
+		target := filepath.Join(registeredTempDir, fmt.Sprintf("%d-%s", len(registered), path.Base(filePath)))
+		if err = ioutil.WriteFile(target, data, 0600); err != nil {
+			return errs.Wrap(err)
+		}
+		if err = registerFile(target); err != nil {
+			return err
+		}
+	}
+	registered[key] = true
+	return nil
+}
+
+func isFontFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ttf", ".otf", ".ttc", ".otc":
+		return true
+	default:
+		return false
+	}
+}
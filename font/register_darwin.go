@@ -0,0 +1,22 @@
+package font
+
+import (
+	// #cgo darwin LDFLAGS: -framework Cocoa
+	// #include <CoreText/CoreText.h>
+	// #include <stdlib.h>
+	"C"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+func platformRegisterFile(filePath string) error {
+	cstr := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cstr))
+	url := C.CFURLCreateFromFileSystemRepresentation(0, (*C.UInt8)(unsafe.Pointer(cstr)), C.CFIndex(len(filePath)), 0)
+	defer C.CFRelease(C.CFTypeRef(url))
+	if C.CTFontManagerRegisterFontsForURL(url, C.kCTFontManagerScopeProcess, nil) == 0 {
+		return errs.Newf("unable to register font file: %s", filePath)
+	}
+	return nil
+}
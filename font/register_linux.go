@@ -0,0 +1,23 @@
+package font
+
+import (
+	// #cgo pkg-config: pangocairo pangoft2 fontconfig
+	// #include <fontconfig/fontconfig.h>
+	// #include <pango/pangocairo.h>
+	// #include <pango/pangofc-fontmap.h>
+	"C"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+func platformRegisterFile(filePath string) error {
+	cstr := C.CString(filePath)
+	defer C.g_free(C.gpointer(cstr))
+	if C.FcConfigAppFontAddFile(nil, (*C.FcChar8)(unsafe.Pointer(cstr))) == C.FcFalse {
+		return errs.Newf("unable to register font file: %s", filePath)
+	}
+	// Let Pango know the set of available fonts has changed, so that its caches are refreshed.
+	C.pango_fc_font_map_config_changed((*C.PangoFcFontMap)(unsafe.Pointer(C.pango_cairo_font_map_get_default())))
+	return nil
+}
@@ -0,0 +1,10 @@
+package font
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+func platformRegisterFile(filePath string) error {
+	// RAW: Implement for Windows
+	return errs.New("font registration is not implemented on Windows")
+}
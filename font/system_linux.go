@@ -1,15 +1,159 @@
 package font
 
+import (
+	// #cgo pkg-config: pangocairo fontconfig
+	// #include <fontconfig/fontconfig.h>
+	// #include <pango/pangocairo.h>
+	"C"
+	"unsafe"
+)
+
+const (
+	// The resolution the desktop's font sizes are based on. Our font sizes are in pixels at a
+	// display scale of 1, which corresponds to this resolution.
+	desktopDPI = 96
+	// The size of the System font when the desktop doesn't provide one.
+	defaultSystemSize = 13
+)
+
+// DesktopSettings holds the font-related settings of the desktop environment, as published by its
+// settings manager.
+type DesktopSettings struct {
+	// FontName holds the Pango description of the user interface font, such as "Cantarell 11".
+	// As is usual for the desktop, sizes are in points at 96 dots per inch.
+	FontName string
+	// Antialias is 1 to enable antialiasing, 0 to disable it, or -1 for the default.
+	Antialias int
+	// Hinting is 1 to enable hinting, 0 to disable it, or -1 for the default.
+	Hinting int
+	// HintStyle is one of "hintnone", "hintslight", "hintmedium" or "hintfull", or empty for the
+	// default.
+	HintStyle string
+	// RGBA is the subpixel order, one of "none", "rgb", "bgr", "vrgb" or "vbgr", or empty for the
+	// default.
+	RGBA string
+}
+
 func init() {
-	// RAW: Try to determine what the user set in system preferences...
-	User = NewFont("Sans 12")
-	UserMonospaced = NewFont("Monospace 10")
-	System = NewFont("Sans 13")
-	EmphasizedSystem = NewFont("Sans Bold 13")
-	SmallSystem = NewFont("Sans 11")
-	SmallEmphasizedSystem = NewFont("Sans Bold 11")
-	Views = NewFont("Sans 12")
-	Label = NewFont("Sans 10")
-	Menu = NewFont("Sans 14")
-	MenuCmdKey = NewFont("Sans 14")
+	ApplyDesktopSettings(DesktopSettings{Antialias: -1, Hinting: -1})
+}
+
+// ApplyDesktopSettings updates the standard fonts, such as System and Label, along with the font
+// rendering options, to reflect the desktop's settings. When the desktop doesn't provide a font,
+// fontconfig's default sans-serif family is used. Standard fonts that already exist are updated in
+// place, so widgets using them pick up the change the next time they are laid out and drawn.
+func ApplyDesktopSettings(settings DesktopSettings) {
+	base := NewFont(settings.FontName)
+	if base.Family() == "" {
+		base.SetFamily(defaultFamily("sans-serif"))
+	}
+	size := float64(defaultSystemSize)
+	if C.pango_font_description_get_size(base.pfd) != 0 {
+		size = base.Size()
+		if C.pango_font_description_get_size_is_absolute(base.pfd) == 0 {
+			size = size * desktopDPI / 72
+		}
+	}
+	mono := base.Copy()
+	mono.SetFamily(defaultFamily("monospace"))
+	updateStandardFont(&User, base, size-1, false)
+	updateStandardFont(&UserMonospaced, mono, size-3, false)
+	updateStandardFont(&System, base, size, false)
+	updateStandardFont(&EmphasizedSystem, base, size, true)
+	updateStandardFont(&SmallSystem, base, size-2, false)
+	updateStandardFont(&SmallEmphasizedSystem, base, size-2, true)
+	updateStandardFont(&Views, base, size-1, false)
+	updateStandardFont(&Label, base, size-3, false)
+	updateStandardFont(&Menu, base, size+1, false)
+	updateStandardFont(&MenuCmdKey, base, size+1, false)
+	base.Dispose()
+	mono.Dispose()
+	setFontOptions(settings)
+}
+
+func updateStandardFont(target **Font, base *Font, size float64, emphasized bool) {
+	f := base.Copy()
+	f.SetSize(size)
+	if emphasized {
+		f.SetWeight(WeightBold)
+	}
+	if *target == nil {
+		*target = f
+		return
+	}
+	C.pango_font_description_free((*target).pfd)
+	(*target).pfd = f.pfd
+	(*target).changed()
+}
+
+// defaultFamily returns the family fontconfig selects for a generic family name, such as
+// "sans-serif" or "monospace".
+func defaultFamily(generic string) string {
+	cstr := C.CString(generic)
+	pattern := C.FcNameParse((*C.FcChar8)(unsafe.Pointer(cstr)))
+	C.g_free(C.gpointer(cstr))
+	if pattern == nil {
+		return generic
+	}
+	defer C.FcPatternDestroy(pattern)
+	C.FcConfigSubstitute(nil, pattern, C.FcMatchPattern)
+	C.FcDefaultSubstitute(pattern)
+	var result C.FcResult
+	match := C.FcFontMatch(nil, pattern, &result)
+	if match == nil {
+		return generic
+	}
+	defer C.FcPatternDestroy(match)
+	object := C.CString("family")
+	defer C.g_free(C.gpointer(object))
+	var family *C.FcChar8
+	if C.FcPatternGetString(match, object, 0, &family) != C.FcResultMatch {
+		return generic
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(family)))
+}
+
+func setFontOptions(settings DesktopSettings) {
+	var options *C.cairo_font_options_t
+	if settings.Antialias != -1 || settings.Hinting != -1 || settings.HintStyle != "" || settings.RGBA != "" {
+		options = C.cairo_font_options_create()
+		subpixel := true
+		switch settings.RGBA {
+		case "rgb":
+			C.cairo_font_options_set_subpixel_order(options, C.CAIRO_SUBPIXEL_ORDER_RGB)
+		case "bgr":
+			C.cairo_font_options_set_subpixel_order(options, C.CAIRO_SUBPIXEL_ORDER_BGR)
+		case "vrgb":
+			C.cairo_font_options_set_subpixel_order(options, C.CAIRO_SUBPIXEL_ORDER_VRGB)
+		case "vbgr":
+			C.cairo_font_options_set_subpixel_order(options, C.CAIRO_SUBPIXEL_ORDER_VBGR)
+		default:
+			subpixel = false
+		}
+		switch settings.Antialias {
+		case 0:
+			C.cairo_font_options_set_antialias(options, C.CAIRO_ANTIALIAS_NONE)
+		case 1:
+			if subpixel {
+				C.cairo_font_options_set_antialias(options, C.CAIRO_ANTIALIAS_SUBPIXEL)
+			} else {
+				C.cairo_font_options_set_antialias(options, C.CAIRO_ANTIALIAS_GRAY)
+			}
+		}
+		if settings.Hinting == 0 {
+			C.cairo_font_options_set_hint_style(options, C.CAIRO_HINT_STYLE_NONE)
+		} else {
+			switch settings.HintStyle {
+			case "hintnone":
+				C.cairo_font_options_set_hint_style(options, C.CAIRO_HINT_STYLE_NONE)
+			case "hintslight":
+				C.cairo_font_options_set_hint_style(options, C.CAIRO_HINT_STYLE_SLIGHT)
+			case "hintmedium":
+				C.cairo_font_options_set_hint_style(options, C.CAIRO_HINT_STYLE_MEDIUM)
+			case "hintfull":
+				C.cairo_font_options_set_hint_style(options, C.CAIRO_HINT_STYLE_FULL)
+			}
+		}
+	}
+	setPangoFontOptions(options)
 }
@@ -0,0 +1,33 @@
+package headless
+
+import (
+	"sync"
+)
+
+var (
+	lock    sync.Mutex
+	pending = sync.NewCond(&lock)
+	queue   []uint64
+)
+
+// Post queues a task ID for dispatch by the next call to Take. May be called
+// from any goroutine.
+func Post(id uint64) {
+	lock.Lock()
+	queue = append(queue, id)
+	lock.Unlock()
+	pending.Signal()
+}
+
+// Take removes and returns all queued task IDs, in the order they were
+// posted. If 'wait' is true and nothing is queued, blocks until something is.
+func Take(wait bool) []uint64 {
+	lock.Lock()
+	for wait && len(queue) == 0 {
+		pending.Wait()
+	}
+	ids := queue
+	queue = nil
+	lock.Unlock()
+	return ids
+}
@@ -23,6 +23,18 @@ func Record(task func()) uint64 {
 	return id
 }
 
+// Cancel a task by ID, preventing it from running if it has not already been
+// dispatched. Returns true if the task was removed.
+func Cancel(id uint64) bool {
+	lock.Lock()
+	_, exists := tasks[id]
+	if exists {
+		delete(tasks, id)
+	}
+	lock.Unlock()
+	return exists
+}
+
 // Dispatch a task by ID.
 func Dispatch(id uint64) {
 	lock.Lock()
@@ -42,6 +42,12 @@ func (evt *ButtonEvent) IsScrollWheel() bool {
 	return evt.button > 3 && evt.button < 8
 }
 
+// IsEmulatedScroll returns true if this wheel button event was emulated by the server for a scroll
+// that has already been reported through the XInput2 scroll valuators of the device.
+func (evt *ButtonEvent) IsEmulatedScroll() bool {
+	return lastScrollTime != 0 && evt.time == lastScrollTime
+}
+
 func (evt *ButtonEvent) ScrollWheelDirection() geom.Point {
 	var result geom.Point
 	switch evt.button {
@@ -30,6 +30,10 @@ func (evt *ClientMessageEvent) Protocol() Atom {
 	return *(*Atom)(unsafe.Pointer(&evt.data))
 }
 
+func (evt *ClientMessageEvent) Atom(index int) Atom {
+	return Atom((*[5]C.long)(unsafe.Pointer(&evt.data))[index])
+}
+
 func (evt *ClientMessageEvent) TaskID() uint64 {
 	return *(*uint64)(unsafe.Pointer(&evt.data))
 }
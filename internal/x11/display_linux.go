@@ -4,6 +4,8 @@ import (
 	// #cgo pkg-config: x11
 	// #include <X11/Xlib.h>
 	"C"
+
+	"github.com/richardwilkes/ui/keys"
 )
 
 const (
@@ -38,6 +40,7 @@ const (
 var (
 	display       *C.Display
 	lastEventTime C.Time
+	taskWindow    Window
 )
 
 type Visual C.Visual
@@ -52,9 +55,13 @@ func OpenDisplay() {
 	}
 	initAtoms()
 	initClipboard()
+	initXInput2()
+	initXSettings()
+	taskWindow = Window(C.XCreateSimpleWindow(display, C.XDefaultRootWindow(display), 0, 0, 1, 1, 0, 0, 0))
 }
 
 func CloseDisplay() {
+	taskWindow.Destroy()
 	C.XCloseDisplay(display)
 	display = nil
 }
@@ -63,6 +70,10 @@ func Running() bool {
 	return display != nil
 }
 
+func InvokeTask(id uint64) {
+	taskWindow.InvokeTask(id)
+}
+
 func NextEvent() *Event {
 	var event Event
 	C.XNextEvent(display, (*C.XEvent)(&event))
@@ -97,3 +108,11 @@ func InputFocus() Window {
 func Flush() {
 	C.XFlush(display)
 }
+
+func CurrentModifiers() keys.Modifiers {
+	var root, child C.Window
+	var rootX, rootY, x, y C.int
+	var mask C.uint
+	C.XQueryPointer(display, C.XDefaultRootWindow(display), &root, &child, &rootX, &rootY, &x, &y, &mask)
+	return Modifiers(mask)
+}
@@ -9,6 +9,14 @@ import (
 
 type PropertyEvent C.XPropertyEvent
 
+func (evt *PropertyEvent) Window() Window {
+	return Window(evt.window)
+}
+
+func (evt *PropertyEvent) Atom() Atom {
+	return Atom(evt.atom)
+}
+
 func (evt *PropertyEvent) Time() C.Time {
 	return evt.time
 }
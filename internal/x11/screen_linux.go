@@ -0,0 +1,81 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11 xrandr
+	// #include <X11/Xlib.h>
+	// #include <X11/extensions/Xrandr.h>
+	"C"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+type Monitor struct {
+	Bounds       geom.Rect
+	PhysicalSize geom.Size
+	Primary      bool
+}
+
+func XftDPI() float64 {
+	if display == nil {
+		return 0
+	}
+	// Prefer the value from the settings manager, which is in 1024ths of a dot per inch, as it
+	// tracks changes made while running.
+	if dpi, ok := xsettings["Xft/DPI"].(int); ok && dpi > 0 {
+		return float64(dpi) / 1024
+	}
+	resources := C.XResourceManagerString(display)
+	if resources == nil {
+		return 0
+	}
+	for _, line := range strings.Split(C.GoString(resources), "\n") {
+		if strings.HasPrefix(line, "Xft.dpi:") {
+			if dpi, err := strconv.ParseFloat(strings.TrimSpace(line[len("Xft.dpi:"):]), 64); err == nil && dpi > 0 {
+				return dpi
+			}
+		}
+	}
+	return 0
+}
+
+func Monitors() []Monitor {
+	if display == nil {
+		return nil
+	}
+	var count C.int
+	info := C.XRRGetMonitors(display, C.XDefaultRootWindow(display), C.True, &count)
+	if info == nil || count == 0 {
+		screen := C.XDefaultScreen(display)
+		return []Monitor{{
+			Bounds:       geom.Rect{Size: geom.Size{Width: float64(C.XDisplayWidth(display, screen)), Height: float64(C.XDisplayHeight(display, screen))}},
+			PhysicalSize: geom.Size{Width: float64(C.XDisplayWidthMM(display, screen)), Height: float64(C.XDisplayHeightMM(display, screen))},
+			Primary:      true,
+		}}
+	}
+	defer C.XRRFreeMonitors(info)
+	monitors := make([]Monitor, 0, int(count))
+	for _, one := range (*[1 << 10]C.XRRMonitorInfo)(unsafe.Pointer(info))[:count:count] {
+		monitors = append(monitors, Monitor{
+			Bounds:       geom.Rect{Point: geom.Point{X: float64(one.x), Y: float64(one.y)}, Size: geom.Size{Width: float64(one.width), Height: float64(one.height)}},
+			PhysicalSize: geom.Size{Width: float64(one.mwidth), Height: float64(one.mheight)},
+			Primary:      one.primary != 0,
+		})
+	}
+	return monitors
+}
+
+func PrimaryMonitor() Monitor {
+	monitors := Monitors()
+	if len(monitors) == 0 {
+		return Monitor{Bounds: geom.Rect{Size: geom.Size{Width: 1024, Height: 768}}}
+	}
+	for _, one := range monitors {
+		if one.Primary {
+			return one
+		}
+	}
+	return monitors[0]
+}
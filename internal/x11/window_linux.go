@@ -89,6 +89,7 @@ func (wnd Window) setWindowHints(bounds geom.Rect) {
 
 func (wnd Window) applyCommonSetup() {
 	wnd.SelectInput(KeyPressMask | KeyReleaseMask | ButtonPressMask | ButtonReleaseMask | EnterWindowMask | LeaveWindowMask | ExposureMask | PointerMotionMask | ExposureMask | VisibilityChangeMask | StructureNotifyMask | FocusChangeMask)
+	wnd.selectXInput2Events()
 	wnd.SetProtocols(DeleteWindowSubType)
 	pid := os.Getpid()
 	wnd.ChangeProperty(wmPidAtom, C.XA_CARDINAL, 32, PropModeReplace, unsafe.Pointer(&pid), 1)
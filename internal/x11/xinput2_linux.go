@@ -0,0 +1,238 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11 xi
+	// #include <stdlib.h>
+	// #include <X11/Xlib.h>
+	// #include <X11/extensions/XInput.h>
+	// #include <X11/extensions/XInput2.h>
+	//
+	// static int xiMaskIsSet(unsigned char *mask, int bit) {
+	//     return XIMaskIsSet(mask, bit);
+	// }
+	//
+	// static void xiSetMask(unsigned char *mask, int bit) {
+	//     XISetMask(mask, bit);
+	// }
+	"C"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+const (
+	XIDeviceChangedType = 1
+	XIMotionType        = 6
+	XITouchBeginType    = 18
+	XITouchUpdateType   = 19
+	XITouchEndType      = 20
+)
+
+type GenericEvent C.XGenericEventCookie
+
+type DeviceEvent C.XIDeviceEvent
+
+type scrollAxis struct {
+	number    int
+	vertical  bool
+	increment float64
+	last      float64
+	valid     bool
+}
+
+type scrollDevice struct {
+	precise bool
+	axes    []*scrollAxis
+}
+
+var (
+	xiAvailable   bool
+	xiTouch       bool
+	xiOpcode      C.int
+	scrollDevices = make(map[C.int]*scrollDevice)
+	// The server time of the last motion event whose scroll valuators produced a scroll delta. The
+	// server emulates wheel button presses for legacy clients with the same time.
+	lastScrollTime C.Time
+)
+
+func initXInput2() {
+	name := C.CString("XInputExtension")
+	defer C.free(unsafe.Pointer(name))
+	var event, errorBase C.int
+	if C.XQueryExtension(display, name, &xiOpcode, &event, &errorBase) == C.False {
+		return
+	}
+	major := C.int(2)
+	minor := C.int(2)
+	if C.XIQueryVersion(display, &major, &minor) != C.Success || major < 2 || (major == 2 && minor < 1) {
+		return
+	}
+	xiAvailable = true
+	xiTouch = major > 2 || minor >= 2
+	refreshScrollDevices()
+}
+
+func refreshScrollDevices() {
+	scrollDevices = make(map[C.int]*scrollDevice)
+	touchpads := touchpadDeviceIDs()
+	var count C.int
+	info := C.XIQueryDevice(display, C.XIAllDevices, &count)
+	if info == nil {
+		return
+	}
+	defer C.XIFreeDeviceInfo(info)
+	for _, dev := range (*[1 << 16]C.XIDeviceInfo)(unsafe.Pointer(info))[:count:count] {
+		classes := (*[1 << 16]*C.XIAnyClassInfo)(unsafe.Pointer(dev.classes))[:dev.num_classes:dev.num_classes]
+		var sd *scrollDevice
+		for _, class := range classes {
+			if class._type == C.XIScrollClass {
+				sc := (*C.XIScrollClassInfo)(unsafe.Pointer(class))
+				if sd == nil {
+					sd = &scrollDevice{precise: touchpads[dev.deviceid]}
+				}
+				sd.axes = append(sd.axes, &scrollAxis{number: int(sc.number), vertical: sc.scroll_type == C.XIScrollTypeVertical, increment: float64(sc.increment)})
+			}
+		}
+		if sd == nil {
+			continue
+		}
+		for _, class := range classes {
+			if class._type == C.XIValuatorClass {
+				vc := (*C.XIValuatorClassInfo)(unsafe.Pointer(class))
+				for _, axis := range sd.axes {
+					if axis.number == int(vc.number) {
+						axis.last = float64(vc.value)
+						axis.valid = true
+					}
+				}
+			}
+		}
+		scrollDevices[dev.deviceid] = sd
+	}
+}
+
+func touchpadDeviceIDs() map[C.int]bool {
+	result := make(map[C.int]bool)
+	touchpad := C.Atom(InternAtom("TOUCHPAD"))
+	var count C.int
+	info := C.XListInputDevices(display, &count)
+	if info == nil {
+		return result
+	}
+	defer C.XFreeDeviceList(info)
+	for _, dev := range (*[1 << 16]C.XDeviceInfo)(unsafe.Pointer(info))[:count:count] {
+		if dev._type == touchpad {
+			result[C.int(dev.id)] = true
+		}
+	}
+	return result
+}
+
+// ResetScrollAxes forgets the last known position of each scroll valuator, as the valuators may
+// have changed while the pointer was outside of our windows.
+func ResetScrollAxes() {
+	for _, sd := range scrollDevices {
+		for _, axis := range sd.axes {
+			axis.valid = false
+		}
+	}
+}
+
+func (wnd Window) selectXInput2Events() {
+	if !xiAvailable {
+		return
+	}
+	var mask [(XITouchEndType >> 3) + 1]C.uchar
+	C.xiSetMask(&mask[0], XIMotionType)
+	C.xiSetMask(&mask[0], XIDeviceChangedType)
+	if xiTouch {
+		C.xiSetMask(&mask[0], XITouchBeginType)
+		C.xiSetMask(&mask[0], XITouchUpdateType)
+		C.xiSetMask(&mask[0], XITouchEndType)
+	}
+	eventMask := C.XIEventMask{deviceid: C.XIAllMasterDevices, mask_len: C.int(len(mask)), mask: &mask[0]}
+	C.XISelectEvents(display, C.Window(wnd), &eventMask, 1)
+}
+
+func (evt *Event) ToGenericEvent() *GenericEvent {
+	return (*GenericEvent)(unsafe.Pointer(evt))
+}
+
+func (evt *GenericEvent) Load() bool {
+	return xiAvailable && evt.extension == xiOpcode && C.XGetEventData(display, (*C.XGenericEventCookie)(evt)) != C.False
+}
+
+func (evt *GenericEvent) Free() {
+	C.XFreeEventData(display, (*C.XGenericEventCookie)(evt))
+}
+
+func (evt *GenericEvent) EvType() int {
+	return int(evt.evtype)
+}
+
+func (evt *GenericEvent) Process() bool {
+	switch evt.evtype {
+	case XIDeviceChangedType:
+		refreshScrollDevices()
+		return false
+	}
+	return true
+}
+
+func (evt *GenericEvent) ToDeviceEvent() *DeviceEvent {
+	return (*DeviceEvent)(evt.data)
+}
+
+func (evt *DeviceEvent) Window() Window {
+	return Window(evt.event)
+}
+
+func (evt *DeviceEvent) Where() geom.Point {
+	return geom.Point{X: float64(evt.event_x), Y: float64(evt.event_y)}
+}
+
+func (evt *DeviceEvent) TouchID() int {
+	return int(evt.detail)
+}
+
+func (evt *DeviceEvent) Modifiers() keys.Modifiers {
+	return Modifiers(C.uint(evt.mods.effective))
+}
+
+func (evt *DeviceEvent) ScrollDelta() (delta geom.Point, precise, ok bool) {
+	sd, exists := scrollDevices[evt.sourceid]
+	if !exists || evt.valuators.mask_len == 0 {
+		return delta, false, false
+	}
+	values := (*[1 << 16]C.double)(unsafe.Pointer(evt.valuators.values))
+	bits := int(evt.valuators.mask_len) * 8
+	index := 0
+	for bit := 0; bit < bits; bit++ {
+		if C.xiMaskIsSet(evt.valuators.mask, C.int(bit)) == 0 {
+			continue
+		}
+		value := float64(values[index])
+		index++
+		for _, axis := range sd.axes {
+			if axis.number != bit {
+				continue
+			}
+			if axis.valid && axis.increment != 0 {
+				amt := (value - axis.last) / axis.increment
+				if axis.vertical {
+					delta.Y += amt
+				} else {
+					delta.X += amt
+				}
+				ok = true
+			}
+			axis.last = value
+			axis.valid = true
+		}
+	}
+	if ok {
+		lastScrollTime = evt.time
+	}
+	return delta, sd.precise, ok
+}
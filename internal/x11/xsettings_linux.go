@@ -0,0 +1,158 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11
+	// #include <X11/Xlib.h>
+	"C"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/font"
+)
+
+const (
+	xsettingsIntegerType = iota
+	xsettingsStringType
+	xsettingsColorType
+)
+
+var (
+	xsettingsSelectionAtom Atom
+	xsettingsAtom          Atom
+	managerAtom            Atom
+	xsettingsOwner         Window
+	xsettings              = make(map[string]interface{})
+)
+
+func initXSettings() {
+	xsettingsSelectionAtom = InternAtom(fmt.Sprintf("_XSETTINGS_S%d", DefaultScreen()))
+	xsettingsAtom = InternAtom("_XSETTINGS_SETTINGS")
+	managerAtom = InternAtom("MANAGER")
+	// A settings manager announces itself with a MANAGER client message sent to the root window.
+	DefaultRootWindow().SelectInput(StructureNotifyMask)
+	loadXSettings()
+}
+
+func loadXSettings() {
+	xsettings = make(map[string]interface{})
+	C.XGrabServer(display)
+	xsettingsOwner = Window(C.XGetSelectionOwner(display, C.Atom(xsettingsSelectionAtom)))
+	if xsettingsOwner != 0 {
+		xsettingsOwner.SelectInput(PropertyChangeMask | StructureNotifyMask)
+	}
+	C.XUngrabServer(display)
+	if xsettingsOwner != 0 {
+		actualType, actualFormat, count, data := xsettingsOwner.Property(xsettingsAtom, xsettingsAtom)
+		if data != nil {
+			if actualType == xsettingsAtom && actualFormat == 8 {
+				xsettings = parseXSettings(C.GoBytes(data, C.int(count)))
+			}
+			C.XFree(data)
+		}
+	}
+}
+
+func parseXSettings(data []byte) map[string]interface{} {
+	settings := make(map[string]interface{})
+	if len(data) < 12 {
+		return settings
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == C.MSBFirst {
+		order = binary.BigEndian
+	}
+	count := int(order.Uint32(data[8:]))
+	pos := 12
+	for i := 0; i < count; i++ {
+		if pos+4 > len(data) {
+			break
+		}
+		settingType := data[pos]
+		nameLength := int(order.Uint16(data[pos+2:]))
+		pos += 4
+		if pos+padToFour(nameLength)+4 > len(data) {
+			break
+		}
+		name := string(data[pos : pos+nameLength])
+		// Skip the name and the serial of the last change
+		pos += padToFour(nameLength) + 4
+		switch settingType {
+		case xsettingsIntegerType:
+			if pos+4 > len(data) {
+				return settings
+			}
+			settings[name] = int(int32(order.Uint32(data[pos:])))
+			pos += 4
+		case xsettingsStringType:
+			if pos+4 > len(data) {
+				return settings
+			}
+			length := int(order.Uint32(data[pos:]))
+			pos += 4
+			if pos+length > len(data) {
+				return settings
+			}
+			settings[name] = string(data[pos : pos+length])
+			pos += padToFour(length)
+		case xsettingsColorType:
+			if pos+8 > len(data) {
+				return settings
+			}
+			settings[name] = color.RGBA(int(order.Uint16(data[pos:])>>8), int(order.Uint16(data[pos+2:])>>8), int(order.Uint16(data[pos+4:])>>8), float64(order.Uint16(data[pos+6:]))/0xFFFF)
+			pos += 8
+		default:
+			return settings
+		}
+	}
+	return settings
+}
+
+func padToFour(length int) int {
+	return (length + 3) &^ 3
+}
+
+func XSettings() map[string]interface{} {
+	return xsettings
+}
+
+func DesktopFontSettings() font.DesktopSettings {
+	settings := font.DesktopSettings{Antialias: -1, Hinting: -1}
+	if value, ok := xsettings["Gtk/FontName"].(string); ok {
+		settings.FontName = value
+	}
+	if value, ok := xsettings["Xft/Antialias"].(int); ok {
+		settings.Antialias = value
+	}
+	if value, ok := xsettings["Xft/Hinting"].(int); ok {
+		settings.Hinting = value
+	}
+	if value, ok := xsettings["Xft/HintStyle"].(string); ok {
+		settings.HintStyle = value
+	}
+	if value, ok := xsettings["Xft/RGBA"].(string); ok {
+		settings.RGBA = value
+	}
+	return settings
+}
+
+func ProcessXSettingsEvent(evt *Event) bool {
+	switch evt.Type() {
+	case PropertyNotifyType:
+		if pe := evt.ToPropertyEvent(); xsettingsOwner != 0 && pe.Window() == xsettingsOwner && pe.Atom() == xsettingsAtom {
+			loadXSettings()
+			return true
+		}
+	case DestroyNotifyType:
+		if xsettingsOwner != 0 && evt.ToDestroyWindowEvent().Window() == xsettingsOwner {
+			loadXSettings()
+			return true
+		}
+	case ClientMessageType:
+		if cm := evt.ToClientMessageEvent(); cm.SubType() == managerAtom && cm.Format() == 32 && cm.Atom(1) == xsettingsSelectionAtom {
+			loadXSettings()
+			return true
+		}
+	}
+	return false
+}
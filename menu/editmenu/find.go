@@ -0,0 +1,124 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/find"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/widget/findbar"
+	"github.com/richardwilkes/ui/window"
+)
+
+// AppendFindItem appends the standard Find menu item to the specified menu.
+func AppendFindItem(m menu.Menu) {
+	InsertFindItem(m, -1)
+}
+
+// InsertFindItem adds the standard Find menu item to the specified menu.
+func InsertFindItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKey(i18n.Text("Find…"), keys.VirtualKeyF, Find)
+	item.EventHandlers().Add(event.ValidateType, CanFind)
+	m.InsertItem(item, index)
+}
+
+// AppendFindNextItem appends the standard Find Next menu item to the specified menu.
+func AppendFindNextItem(m menu.Menu) {
+	InsertFindNextItem(m, -1)
+}
+
+// InsertFindNextItem adds the standard Find Next menu item to the specified menu.
+func InsertFindNextItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKey(i18n.Text("Find Next"), keys.VirtualKeyG, FindNext)
+	item.EventHandlers().Add(event.ValidateType, CanFindAgain)
+	m.InsertItem(item, index)
+}
+
+// AppendFindPreviousItem appends the standard Find Previous menu item to the specified menu.
+func AppendFindPreviousItem(m menu.Menu) {
+	InsertFindPreviousItem(m, -1)
+}
+
+// InsertFindPreviousItem adds the standard Find Previous menu item to the specified menu.
+func InsertFindPreviousItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKeyAndModifiers(i18n.Text("Find Previous"), keys.VirtualKeyG, keys.PlatformMenuModifier()|keys.ShiftModifier, FindPrevious)
+	item.EventHandlers().Add(event.ValidateType, CanFindAgain)
+	m.InsertItem(item, index)
+}
+
+// Find activates the Find bar in the key window, showing one first if the window doesn't have one,
+// and targets the current keyboard focus if it can be searched.
+func Find(evt event.Event) {
+	wnd := window.KeyWindow()
+	if wnd != nil {
+		if bar := findbar.Show(wnd); bar != nil {
+			if f, ok := wnd.Focus().(find.Findable); ok {
+				bar.SetTarget(f)
+			}
+			bar.Activate()
+		}
+	}
+}
+
+// CanFind returns true if Find() can be called successfully.
+func CanFind(evt event.Event) {
+	wnd := window.KeyWindow()
+	if wnd == nil || !findbar.CanShow(wnd) {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// FindNext selects the next match for the options last used for searching.
+func FindNext(evt event.Event) {
+	if target, matcher := findTarget(); target != nil {
+		target.FindNext(matcher)
+	}
+}
+
+// FindPrevious selects the previous match for the options last used for searching.
+func FindPrevious(evt event.Event) {
+	if target, matcher := findTarget(); target != nil {
+		target.FindPrevious(matcher)
+	}
+}
+
+// CanFindAgain returns true if FindNext() and FindPrevious() can be called successfully.
+func CanFindAgain(evt event.Event) {
+	if target, _ := findTarget(); target == nil {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// findTarget returns the target of the Find bar in the key window, if there is one, or the key
+// window's keyboard focus if it can be searched, along with a matcher for the options last used
+// for searching.
+func findTarget() (find.Findable, *find.Matcher) {
+	wnd := window.KeyWindow()
+	if wnd == nil {
+		return nil, nil
+	}
+	matcher, err := find.NewMatcher(find.LastOptions())
+	if err != nil {
+		return nil, nil
+	}
+	var target find.Findable
+	if bar := findbar.InWindow(wnd); bar != nil && bar.Target() != nil {
+		target = bar.Target()
+	} else if f, ok := wnd.Focus().(find.Findable); ok && !isWithinFindBar(wnd.Focus()) {
+		target = f
+	}
+	if target == nil {
+		return nil, nil
+	}
+	return target, matcher
+}
+
+func isWithinFindBar(w ui.Widget) bool {
+	for ; w != nil; w = w.Parent() {
+		if _, ok := w.(*findbar.FindBar); ok {
+			return true
+		}
+	}
+	return false
+}
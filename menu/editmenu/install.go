@@ -17,6 +17,11 @@ func Install(bar menu.Bar) menu.Menu {
 	AppendDeleteItem(editMenu)
 	AppendSelectAllItem(editMenu)
 
+	editMenu.AppendItem(menu.NewSeparator())
+	AppendFindItem(editMenu)
+	AppendFindNextItem(editMenu)
+	AppendFindPreviousItem(editMenu)
+
 	bar.AppendMenu(editMenu)
 	return editMenu
 }
@@ -0,0 +1,146 @@
+package print
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Format holds the type of document a Job produces.
+type Format int
+
+// Possible Format values.
+const (
+	PDF Format = iota
+	PostScript
+)
+
+// Job holds the information needed to print a Printable.
+type Job struct {
+	Title     string
+	Setup     *PageSetup
+	Format    Format
+	Printer   string // The destination passed to lp. Empty means the system default.
+	Copies    int
+	printable Printable
+}
+
+// NewJob creates a new print job for the Printable using the default page setup.
+func NewJob(title string, printable Printable) *Job {
+	return &Job{Title: title, Setup: NewPageSetup(), Copies: 1, printable: printable}
+}
+
+// Printable returns the content this job prints.
+func (job *Job) Printable() Printable {
+	return job.printable
+}
+
+// PageCount returns the number of pages the job will produce.
+func (job *Job) PageCount() int {
+	return job.printable.PageCount(job.Setup)
+}
+
+// DrawPage draws the page at 'pageIndex' into the graphics context, whose origin is assumed to be
+// the top-left corner of the page.
+func (job *Job) DrawPage(gc *draw.Graphics, pageIndex int) {
+	bounds := job.Setup.ImageableBounds()
+	gc.Save()
+	gc.Rect(bounds)
+	gc.Clip()
+	gc.Translate(bounds.X, bounds.Y)
+	job.printable.PrintPage(gc, pageIndex)
+	gc.Restore()
+}
+
+// Render writes every page of the job to 'w' in the job's format.
+func (job *Job) Render(w io.Writer) error {
+	size := job.Setup.PageSize()
+	var surface *draw.Surface
+	switch job.Format {
+	case PDF:
+		surface = draw.NewPDFSurface(w, size)
+	case PostScript:
+		surface = draw.NewPSSurface(w, size)
+	default:
+		return errs.Newf("unknown print format: %d", job.Format)
+	}
+	defer surface.Destroy()
+	gc := draw.NewGraphics(surface.NewCairoContext())
+	count := job.PageCount()
+	for i := 0; i < count; i++ {
+		job.DrawPage(gc, i)
+		surface.ShowPage()
+	}
+	gc.Dispose()
+	return surface.Finish()
+}
+
+// RenderToFile writes every page of the job to the file at 'path' in the job's format.
+func (job *Job) RenderToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	buffer := bufio.NewWriter(f)
+	if err = job.Render(buffer); err != nil {
+		f.Close()
+		return err
+	}
+	if err = buffer.Flush(); err != nil {
+		f.Close()
+		return errs.Wrap(err)
+	}
+	if err = f.Close(); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// CanPrint returns true if the lp command is available for sending jobs to a printer.
+func CanPrint() bool {
+	_, err := exec.LookPath("lp")
+	return err == nil
+}
+
+// Print renders the job to a temporary file and hands it to the lp command for printing.
+func (job *Job) Print() error {
+	lp, err := exec.LookPath("lp")
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	ext := ".pdf"
+	if job.Format == PostScript {
+		ext = ".ps"
+	}
+	f, err := ioutil.TempFile("", "print-*"+ext)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+	if err = job.RenderToFile(path); err != nil {
+		return err
+	}
+	args := []string{"-o", "media=" + job.Setup.Paper.Name}
+	if job.Printer != "" {
+		args = append(args, "-d", job.Printer)
+	}
+	if job.Copies > 1 {
+		args = append(args, "-n", strconv.Itoa(job.Copies))
+	}
+	if job.Title != "" {
+		args = append(args, "-t", job.Title)
+	}
+	args = append(args, path)
+	if out, err := exec.Command(lp, args...).CombinedOutput(); err != nil {
+		return errs.NewWithCause(string(out), err)
+	}
+	return nil
+}
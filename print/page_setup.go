@@ -0,0 +1,64 @@
+package print
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// Orientation holds the direction the content of a page is placed upon the paper.
+type Orientation int
+
+// Possible Orientation values.
+const (
+	Portrait Orientation = iota
+	Landscape
+)
+
+// PaperSize holds the name and portrait dimensions, in points, of a sheet of paper.
+type PaperSize struct {
+	Name string
+	Size geom.Size
+}
+
+// Common paper sizes.
+var (
+	Letter  = PaperSize{Name: "Letter", Size: geom.Size{Width: 612, Height: 792}}
+	Legal   = PaperSize{Name: "Legal", Size: geom.Size{Width: 612, Height: 1008}}
+	Tabloid = PaperSize{Name: "Tabloid", Size: geom.Size{Width: 792, Height: 1224}}
+	A3      = PaperSize{Name: "A3", Size: geom.Size{Width: 841.89, Height: 1190.55}}
+	A4      = PaperSize{Name: "A4", Size: geom.Size{Width: 595.28, Height: 841.89}}
+	A5      = PaperSize{Name: "A5", Size: geom.Size{Width: 419.53, Height: 595.28}}
+)
+
+// PageSetup holds the paper, margins and orientation used when printing.
+type PageSetup struct {
+	Paper       PaperSize
+	Margins     geom.Insets // In points, relative to the oriented page.
+	Orientation Orientation
+}
+
+// NewPageSetup creates a new PageSetup for US Letter paper in portrait orientation with half-inch
+// margins.
+func NewPageSetup() *PageSetup {
+	return &PageSetup{Paper: Letter, Margins: geom.NewUniformInsets(36)}
+}
+
+// Clone returns a copy of the PageSetup.
+func (setup *PageSetup) Clone() *PageSetup {
+	other := *setup
+	return &other
+}
+
+// PageSize returns the size of the page, in points, taking the orientation into account.
+func (setup *PageSetup) PageSize() geom.Size {
+	if setup.Orientation == Landscape {
+		return geom.Size{Width: setup.Paper.Size.Height, Height: setup.Paper.Size.Width}
+	}
+	return setup.Paper.Size
+}
+
+// ImageableBounds returns the area of the page, in points, that lies within the margins.
+func (setup *PageSetup) ImageableBounds() geom.Rect {
+	bounds := geom.Rect{Size: setup.PageSize()}
+	bounds.Inset(setup.Margins)
+	return bounds
+}
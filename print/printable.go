@@ -0,0 +1,15 @@
+package print
+
+import (
+	"github.com/richardwilkes/ui/draw"
+)
+
+// Printable is implemented by content that can be printed.
+type Printable interface {
+	// PageCount returns the number of pages needed to print the content using the specified page
+	// setup.
+	PageCount(setup *PageSetup) int
+	// PrintPage draws the page at 'pageIndex'. The graphics context has been translated so that
+	// its origin is at the top-left corner of the page's imageable bounds, and clipped to them.
+	PrintPage(gc *draw.Graphics, pageIndex int)
+}
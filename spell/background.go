@@ -0,0 +1,113 @@
+package spell
+
+import (
+	"sync"
+)
+
+const maxCachedResults = 10000
+
+// Background checks text for misspellings on a separate goroutine, delivering the results on the
+// UI thread. The result for each word is remembered, so that as text is edited, only the words
+// that changed need to be passed to the Checker again.
+type Background struct {
+	lock       sync.Mutex
+	checker    Checker
+	invoke     func(func())
+	done       func(misspelled []Range)
+	results    map[string]bool
+	revision   uint64
+	generation uint64
+}
+
+// NewBackground creates a new Background. 'invoke' must schedule a task to be run on the UI
+// thread, as app.Invoke does. 'done' will be called on the UI thread with the misspelled words
+// found by the most recent call to Check.
+func NewBackground(invoke func(func()), done func(misspelled []Range)) *Background {
+	return &Background{invoke: invoke, done: done, results: make(map[string]bool)}
+}
+
+// Checker returns the Checker being used, if any.
+func (b *Background) Checker() Checker {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.checker
+}
+
+// SetChecker sets the Checker to use. Pass in nil to disable checking.
+func (b *Background) SetChecker(checker Checker) {
+	b.lock.Lock()
+	b.checker = checker
+	b.results = make(map[string]bool)
+	b.generation++
+	b.lock.Unlock()
+}
+
+// Check starts checking 'text', abandoning any check that is still in progress. If no Checker
+// has been set, the results are delivered immediately, with no misspelled words.
+func (b *Background) Check(text string) {
+	b.lock.Lock()
+	b.generation++
+	generation := b.generation
+	checker := b.checker
+	if revision := currentRevision(); b.revision != revision || len(b.results) > maxCachedResults {
+		b.revision = revision
+		b.results = make(map[string]bool)
+	}
+	results := b.results
+	b.lock.Unlock()
+	if checker == nil {
+		b.done(nil)
+		return
+	}
+	go func() {
+		misspelled, ok := b.check(checker, results, text, generation)
+		if ok {
+			b.invoke(func() {
+				if b.current(generation) {
+					b.done(misspelled)
+				}
+			})
+		}
+	}()
+}
+
+// Cancel abandons any check that is still in progress.
+func (b *Background) Cancel() {
+	b.lock.Lock()
+	b.generation++
+	b.lock.Unlock()
+}
+
+// check returns the misspelled words within 'text', or false if the check was abandoned. Results
+// are recorded in the 'results' map that was current when the check started, so that a change of
+// Checker while a check is in progress doesn't leave stale results behind.
+func (b *Background) check(checker Checker, results map[string]bool, text string, generation uint64) ([]Range, bool) {
+	runes := []rune(text)
+	var misspelled []Range
+	for _, word := range Words(text) {
+		str := string(runes[word.Start:word.End])
+		b.lock.Lock()
+		if b.generation != generation {
+			b.lock.Unlock()
+			return nil, false
+		}
+		correct, exists := results[str]
+		b.lock.Unlock()
+		if !exists {
+			correct = checker.Check(str)
+			b.lock.Lock()
+			results[str] = correct
+			b.lock.Unlock()
+		}
+		if !correct {
+			misspelled = append(misspelled, word)
+		}
+	}
+	return misspelled, true
+}
+
+func (b *Background) current(generation uint64) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.generation == generation
+}
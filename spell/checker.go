@@ -0,0 +1,124 @@
+package spell
+
+import (
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Checker determines whether words are spelled correctly. Its methods may be called from any
+// goroutine.
+type Checker interface {
+	// Check returns true if 'word' is spelled correctly.
+	Check(word string) bool
+	// Suggestions returns up to 'max' replacements for 'word', best first.
+	Suggestions(word string, max int) []string
+	// Learn adds 'word' to the words considered to be spelled correctly.
+	Learn(word string) error
+}
+
+// Range holds the rune indexes of a word, from Start up to, but not including, End.
+type Range struct {
+	Start int
+	End   int
+}
+
+var (
+	lock           sync.RWMutex
+	defaultChecker Checker
+	revision       uint64
+)
+
+// Default returns the Checker that newly created editable text widgets use, or nil if spell
+// checking is disabled by default.
+func Default() Checker {
+	lock.RLock()
+	defer lock.RUnlock()
+	return defaultChecker
+}
+
+// SetDefault sets the Checker that newly created editable text widgets use. Pass in nil to disable
+// spell checking by default.
+func SetDefault(checker Checker) {
+	lock.Lock()
+	defaultChecker = checker
+	revision++
+	lock.Unlock()
+}
+
+// Learn adds 'word' to the words 'checker' considers to be spelled correctly, then causes
+// previously checked text to be checked again.
+func Learn(checker Checker, word string) error {
+	err := checker.Learn(word)
+	lock.Lock()
+	revision++
+	lock.Unlock()
+	return err
+}
+
+func currentRevision() uint64 {
+	lock.RLock()
+	defer lock.RUnlock()
+	return revision
+}
+
+// Words returns the ranges of the words within 'text'. A word is a run of letters and marks,
+// which may contain apostrophes between letters. Words that contain digits are skipped, as they
+// are more likely to be identifiers or codes than words.
+func Words(text string) []Range {
+	var ranges []Range
+	start := -1
+	digits := false
+	index := 0
+	var prev rune
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+		if !inWord && (r == '\'' || r == '’') && start != -1 && unicode.IsLetter(prev) {
+			next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+			inWord = unicode.IsLetter(next)
+		}
+		if inWord {
+			if start == -1 {
+				start = index
+				digits = false
+			}
+			if unicode.IsDigit(r) {
+				digits = true
+			}
+		} else if start != -1 {
+			if !digits {
+				ranges = append(ranges, Range{Start: start, End: index})
+			}
+			start = -1
+		}
+		prev = r
+		index++
+	}
+	if start != -1 && !digits {
+		ranges = append(ranges, Range{Start: start, End: index})
+	}
+	return ranges
+}
+
+// WordAt returns the range of the word containing the rune at 'index' within 'text'.
+func WordAt(text string, index int) (Range, bool) {
+	for _, word := range Words(text) {
+		if index >= word.Start && index < word.End {
+			return word, true
+		}
+	}
+	return Range{}, false
+}
+
+// Misspellings returns the ranges of the words within 'text' that 'checker' considers to be
+// spelled incorrectly.
+func Misspellings(checker Checker, text string) []Range {
+	runes := []rune(text)
+	var ranges []Range
+	for _, word := range Words(text) {
+		if !checker.Check(string(runes[word.Start:word.End])) {
+			ranges = append(ranges, word)
+		}
+	}
+	return ranges
+}
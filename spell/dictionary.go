@@ -0,0 +1,269 @@
+package spell
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// Dictionary is a Checker backed by a list of words, such as those found in the word list files
+// installed on most Unix systems.
+type Dictionary struct {
+	lock         sync.RWMutex
+	words        map[string]bool
+	sorted       []string // The words in lower case, sorted, for finding words by prefix.
+	personalPath string
+}
+
+// NewDictionary creates a new, empty, Dictionary.
+func NewDictionary() *Dictionary {
+	return &Dictionary{words: make(map[string]bool)}
+}
+
+// LoadDictionary creates a new Dictionary from the words in the file at 'path'.
+func LoadDictionary(path string) (*Dictionary, error) {
+	d := NewDictionary()
+	if err := d.AddFile(path); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// AddFile adds the words in the file at 'path' to the Dictionary.
+func (d *Dictionary) AddFile(path string) (err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return errs.NewfWithCause(err, "unable to open dictionary file %s", path)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	return d.AddWords(f)
+}
+
+// AddWords adds words to the Dictionary from 'r', which should contain one word per line. Blank
+// lines and lines starting with '#' are ignored. As with Hunspell .dic files, anything following
+// a '/' on a line is ignored, as is a line containing only a count of the words in the file.
+func (d *Dictionary) AddWords(r io.Reader) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, '/'); i != -1 {
+			line = line[:i]
+		}
+		if first {
+			first = false
+			if isCount(line) {
+				continue
+			}
+		}
+		if line != "" && !strings.HasPrefix(line, "#") && !d.words[line] {
+			d.words[line] = true
+			d.sorted = append(d.sorted, strings.ToLower(line))
+		}
+	}
+	sort.Strings(d.sorted)
+	if err := scanner.Err(); err != nil {
+		return errs.NewWithCause("unable to read dictionary words", err)
+	}
+	return nil
+}
+
+func isCount(line string) bool {
+	for _, r := range line {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return line != ""
+}
+
+// SetPersonalFile sets the file that learned words are appended to, adding any words it already
+// contains to the Dictionary. The file will be created when the first word is learned, if it
+// doesn't already exist.
+func (d *Dictionary) SetPersonalFile(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		if err = d.AddFile(path); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return errs.NewfWithCause(err, "unable to access personal dictionary file %s", path)
+	}
+	d.lock.Lock()
+	d.personalPath = path
+	d.lock.Unlock()
+	return nil
+}
+
+// Check implements Checker. A word is spelled correctly if it is in the Dictionary exactly, or
+// if it is capitalized, or entirely in upper case, and its lower case form is in the Dictionary.
+func (d *Dictionary) Check(word string) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.contains(word)
+}
+
+func (d *Dictionary) contains(word string) bool {
+	if d.words[word] {
+		return true
+	}
+	lower := strings.ToLower(word)
+	if lower == word {
+		return false
+	}
+	if d.words[lower] {
+		return true
+	}
+	first, size := utf8.DecodeRuneInString(lower)
+	return d.words[string(unicode.ToUpper(first))+lower[size:]]
+}
+
+// Suggestions implements Checker. Suggestions are the words in the Dictionary that can be reached
+// with a single edit, then those that can be reached with two, where an edit is the insertion,
+// removal or replacement of a letter, or the swap of two adjacent letters.
+func (d *Dictionary) Suggestions(word string, max int) []string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if max <= 0 || word == "" {
+		return nil
+	}
+	alphabet := d.alphabet(word)
+	seen := make(map[string]bool)
+	var result []string
+	edits := d.edits(word, alphabet)
+	result = d.collect(result, edits, seen, word, max)
+	if len(result) < max && utf8.RuneCountInString(word) <= 12 {
+		var more []string
+		for _, one := range edits {
+			more = append(more, d.edits(one, alphabet)...)
+		}
+		result = d.collect(result, more, seen, word, max)
+	}
+	return result
+}
+
+func (d *Dictionary) collect(result, candidates []string, seen map[string]bool, word string, max int) []string {
+	var found []string
+	for _, one := range candidates {
+		if !seen[one] && one != word && d.contains(one) {
+			seen[one] = true
+			found = append(found, one)
+		}
+	}
+	// Sort, so that the suggestions are stable across calls.
+	sort.Strings(found)
+	for _, one := range found {
+		if len(result) == max {
+			break
+		}
+		result = append(result, matchCase(word, one))
+	}
+	return result
+}
+
+func (d *Dictionary) alphabet(word string) []rune {
+	set := make(map[rune]bool)
+	for r := 'a'; r <= 'z'; r++ {
+		set[r] = true
+	}
+	for _, r := range strings.ToLower(word) {
+		set[r] = true
+	}
+	alphabet := make([]rune, 0, len(set))
+	for r := range set {
+		alphabet = append(alphabet, r)
+	}
+	return alphabet
+}
+
+// hasPrefix returns true if at least one word in the Dictionary starts with the lower case 'prefix'.
+func (d *Dictionary) hasPrefix(prefix string) bool {
+	i := sort.SearchStrings(d.sorted, prefix)
+	return i < len(d.sorted) && strings.HasPrefix(d.sorted[i], prefix)
+}
+
+// edits returns the strings one edit away from 'word'. Every edit at a given position leaves the
+// runes before it unchanged, so once those runes don't begin any word in the Dictionary, the
+// remaining positions can't produce a word and are skipped.
+func (d *Dictionary) edits(word string, alphabet []rune) []string {
+	runes := []rune(strings.ToLower(word))
+	var edits []string
+	for i := 0; i <= len(runes); i++ {
+		if !d.hasPrefix(string(runes[:i])) {
+			break
+		}
+		if i < len(runes) {
+			edits = append(edits, string(runes[:i])+string(runes[i+1:]))
+			if i+1 < len(runes) {
+				edits = append(edits, string(runes[:i])+string(runes[i+1])+string(runes[i])+string(runes[i+2:]))
+			}
+		}
+		for _, r := range alphabet {
+			if i < len(runes) && r != runes[i] {
+				edits = append(edits, string(runes[:i])+string(r)+string(runes[i+1:]))
+			}
+			edits = append(edits, string(runes[:i])+string(r)+string(runes[i:]))
+		}
+	}
+	return edits
+}
+
+func matchCase(original, suggestion string) string {
+	if strings.ToUpper(original) == original && utf8.RuneCountInString(original) > 1 {
+		return strings.ToUpper(suggestion)
+	}
+	if first, _ := utf8.DecodeRuneInString(original); unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(suggestion)
+		return string(unicode.ToUpper(r)) + suggestion[size:]
+	}
+	return suggestion
+}
+
+// Learn implements Checker. If a personal file has been set, the word is also appended to it. An
+// error is only returned if the word couldn't be written to the personal file, in which case it
+// has still been learned for the life of the Dictionary.
+func (d *Dictionary) Learn(word string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if word == "" || d.words[word] {
+		return nil
+	}
+	d.words[word] = true
+	lower := strings.ToLower(word)
+	i := sort.SearchStrings(d.sorted, lower)
+	d.sorted = append(d.sorted, "")
+	copy(d.sorted[i+1:], d.sorted[i:])
+	d.sorted[i] = lower
+	if d.personalPath != "" {
+		return appendLine(d.personalPath, word)
+	}
+	return nil
+}
+
+func appendLine(path, line string) (err error) {
+	var f *os.File
+	if f, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		return errs.NewfWithCause(err, "unable to open personal dictionary file %s", path)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	if _, err = f.WriteString(line + "\n"); err != nil {
+		return errs.NewfWithCause(err, "unable to write to personal dictionary file %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package text
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	//
+	// static int isCursorPosition(const PangoLogAttr *attrs, int index) {
+	//     return attrs[index].is_cursor_position;
+	// }
+	//
+	// static int isWhite(const PangoLogAttr *attrs, int index) {
+	//     return attrs[index].is_white;
+	// }
+	"C"
+	"math"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/font"
+)
+
+// IsCursorPosition returns true if the caret may be placed before the rune at 'index'. Positions
+// within a grapheme cluster, such as between a base character and its combining marks, or within
+// an emoji sequence, are not valid cursor positions.
+func (l *Layout) IsCursorPosition(index int) bool {
+	var count C.gint
+	attrs := C.pango_layout_get_log_attrs_readonly(l.shaped(), &count)
+	if index <= 0 || index >= int(count) {
+		return true
+	}
+	return C.isCursorPosition(attrs, C.int(index)) != 0
+}
+
+// PreviousCursorPosition returns the closest cursor position before 'index', in logical order.
+func (l *Layout) PreviousCursorPosition(index int) int {
+	for index--; index > 0 && !l.IsCursorPosition(index); index-- {
+	}
+	if index < 0 {
+		return 0
+	}
+	return index
+}
+
+// NextCursorPosition returns the closest cursor position after 'index', in logical order.
+func (l *Layout) NextCursorPosition(index int) int {
+	length := runeIndex(l.text, len(l.text))
+	for index++; index < length && !l.IsCursorPosition(index); index++ {
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+// IsWhitespace returns true if the rune at 'index' is whitespace.
+func (l *Layout) IsWhitespace(index int) bool {
+	var count C.gint
+	attrs := C.pango_layout_get_log_attrs_readonly(l.shaped(), &count)
+	if index < 0 || index >= int(count) {
+		return false
+	}
+	return C.isWhite(attrs, C.int(index)) != 0
+}
+
+// MoveCursorVisually returns the cursor position reached by moving one grapheme cluster to the
+// left, when 'direction' is negative, or to the right, when 'direction' is positive, of the
+// cursor position at 'index'. This takes the visual order of bidirectional text into account,
+// so the logical index may move in either direction. If the cursor can't move any further,
+// 'index' is returned.
+func (l *Layout) MoveCursorVisually(index, direction int) int {
+	length := runeIndex(l.text, len(l.text))
+	var newIndex, trailing C.int
+	C.pango_layout_move_cursor_visually(l.shaped(), C.TRUE, C.int(byteIndex(l.text, index)), 0, C.int(direction), &newIndex, &trailing)
+	if newIndex < 0 || newIndex == math.MaxInt32 {
+		return index
+	}
+	result := runeIndex(l.text, int(newIndex)) + int(trailing)
+	if result > length {
+		result = length
+	}
+	return result
+}
+
+// CursorPosition returns the strong and weak caret positions for the cursor position at 'index'.
+// The strong caret is where text of the same direction as the base direction would be inserted,
+// while the weak caret is where text of the opposite direction would be inserted. The returned
+// rectangles have a width of 0 and span the height of the line.
+func (l *Layout) CursorPosition(index int) (strong, weak geom.Rect) {
+	var strongPos, weakPos C.PangoRectangle
+	C.pango_layout_get_cursor_pos(l.shaped(), C.int(byteIndex(l.text, index)), &strongPos, &weakPos)
+	top := l.font.Leading()
+	return fromPangoRect(strongPos, top), fromPangoRect(weakPos, top)
+}
+
+// SelectionRects returns the rectangles that cover the runes from 'start' up to, but not
+// including, 'end'. In bidirectional text, a contiguous logical range may be displayed as several
+// separate visual ranges.
+func (l *Layout) SelectionRects(start, end int) []geom.Rect {
+	if start >= end {
+		return nil
+	}
+	startByte := C.int(byteIndex(l.text, start))
+	endByte := C.int(byteIndex(l.text, end))
+	top := l.font.Leading()
+	count := l.LineCount()
+	var rects []geom.Rect
+	iter := C.pango_layout_get_iter(l.shaped())
+	for i := 0; i < count; i++ {
+		line := C.pango_layout_iter_get_line_readonly(iter)
+		var logical C.PangoRectangle
+		C.pango_layout_iter_get_line_extents(iter, nil, &logical)
+		lineBounds := fromPangoRect(logical, top)
+		var ranges *C.int
+		var n C.int
+		C.pango_layout_line_get_x_ranges(line, startByte, endByte, &ranges, &n)
+		if n > 0 {
+			values := (*[1 << 20]C.int)(unsafe.Pointer(ranges))[: n*2 : n*2]
+			for j := 0; j < int(n); j++ {
+				x1 := float64(values[j*2]) / font.PangoScale
+				x2 := float64(values[j*2+1]) / font.PangoScale
+				rects = append(rects, geom.Rect{Point: geom.Point{X: x1, Y: lineBounds.Y}, Size: geom.Size{Width: x2 - x1, Height: lineBounds.Height}})
+			}
+		}
+		C.g_free(C.gpointer(ranges))
+		if C.pango_layout_iter_next_line(iter) == C.FALSE {
+			break
+		}
+	}
+	C.pango_layout_iter_free(iter)
+	return rects
+}
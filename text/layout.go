@@ -0,0 +1,481 @@
+package text
+
+import (
+	// #cgo pkg-config: pangocairo
+	// #include <pango/pangocairo.h>
+	"C"
+	"runtime"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/font"
+)
+
+// Layout holds a piece of text that has been shaped and broken into lines, ready to be measured
+// and drawn. The shaping is retained between calls, so drawing the same Layout repeatedly is
+// inexpensive. Coordinates are relative to the Layout's origin, which includes the font's
+// leading above the first line, as with font.Font.Measure().
+type Layout struct {
+	layout      *C.PangoLayout
+	text        string
+	attributed  *draw.AttributedString
+	font        *font.Font
+	width       float64
+	wrap        Wrap
+	alignment   Alignment
+	ellipsize   Ellipsize
+	direction   Direction
+	lineSpacing float64
+	maxLines    int
+	// The revision of the font when its description was last applied to the layout.
+	fontRevision uint64
+	// Whether the line spacing still follows the font's leading.
+	leadingSpacing bool
+	// The serial of the Pango context when the layout was last drawn.
+	contextSerial C.guint
+	// The width of the longest line when unwrapped, in Pango units, valid until the text, font,
+	// attributes, direction or context change.
+	natural      C.int
+	naturalValid bool
+}
+
+// LineMetrics holds information about a single line within a Layout.
+type LineMetrics struct {
+	// Bounds holds the logical bounds of the line.
+	Bounds geom.Rect
+	// Baseline holds the y-coordinate of the line's baseline.
+	Baseline float64
+	// Start holds the rune index of the first character of the line.
+	Start int
+	// Length holds the number of runes in the line.
+	Length int
+}
+
+// NewLayout creates a new Layout for 'text' drawn with the font 'f'. By default, the text isn't
+// wrapped, is aligned to the left and uses the font's leading as the spacing between lines.
+func NewLayout(text string, f *font.Font) *Layout {
+	context := (*C.PangoContext)(font.NewPangoContext())
+	layout := &Layout{layout: C.pango_layout_new(context), font: f, leadingSpacing: true}
+	C.g_object_unref(C.gpointer(context))
+	runtime.SetFinalizer(layout, (*Layout).Dispose)
+	layout.applyFont()
+	layout.SetText(text)
+	return layout
+}
+
+// Dispose of the underlying Pango layout. This is done automatically when the Layout is garbage
+// collected, but may be called to release the resources sooner. The Layout must not be used
+// afterwards.
+func (l *Layout) Dispose() {
+	if l.layout != nil {
+		C.g_object_unref(C.gpointer(l.layout))
+		l.layout = nil
+	}
+}
+
+// Text returns the text.
+func (l *Layout) Text() string {
+	return l.text
+}
+
+// SetText sets the text, removing any attributes.
+func (l *Layout) SetText(text string) {
+	l.setText(text, nil)
+}
+
+// AttributedString returns the attributed string, or nil if the text has no attributes.
+func (l *Layout) AttributedString() *draw.AttributedString {
+	return l.attributed
+}
+
+// SetAttributedString sets the text and the attributes that style it. The Layout's font is used
+// for any text without font attributes.
+func (l *Layout) SetAttributedString(str *draw.AttributedString) {
+	l.setText(str.Text(), str)
+}
+
+func (l *Layout) setText(text string, attributed *draw.AttributedString) {
+	l.text = text
+	l.attributed = attributed
+	cstr := C.CString(text)
+	C.pango_layout_set_text(l.layout, cstr, -1)
+	C.g_free(C.gpointer(cstr))
+	l.updateAttributes()
+	l.updateWidth()
+}
+
+// updateAttributes applies the font's features and the attributed string's attributes, if any, to
+// the layout.
+func (l *Layout) updateAttributes() {
+	var list *C.PangoAttrList
+	if l.attributed != nil {
+		list = (*C.PangoAttrList)(l.attributed.PangoAttrList())
+	}
+	if attr := (*C.PangoAttribute)(l.font.PangoFeaturesAttribute()); attr != nil {
+		if list == nil {
+			list = C.pango_attr_list_new()
+		}
+		C.pango_attr_list_insert_before(list, attr)
+	}
+	C.pango_layout_set_attributes(l.layout, list)
+	if list != nil {
+		C.pango_attr_list_unref(list)
+	}
+	l.naturalValid = false
+}
+
+// Font returns the font.
+func (l *Layout) Font() *font.Font {
+	return l.font
+}
+
+// SetFont sets the font. Changes made to the font afterwards are picked up the next time the
+// Layout is measured or drawn.
+func (l *Layout) SetFont(f *font.Font) {
+	l.font = f
+	l.applyFont()
+	l.updateAttributes()
+	l.updateWidth()
+}
+
+// applyFont copies the font's description into the layout. Unless other line spacing has been set,
+// the spacing follows the font's leading.
+func (l *Layout) applyFont() {
+	l.fontRevision = l.font.Revision()
+	C.pango_layout_set_font_description(l.layout, (*C.PangoFontDescription)(l.font.PangoFontDescription()))
+	l.naturalValid = false
+	if l.leadingSpacing {
+		l.setLineSpacing(l.font.Leading())
+	}
+}
+
+// shaped returns the underlying Pango layout, first re-applying the font if it has been modified
+// since it was last applied, as Pango keeps its own copy of the font's description.
+func (l *Layout) shaped() *C.PangoLayout {
+	if l.fontRevision != l.font.Revision() {
+		l.applyFont()
+		l.updateAttributes()
+		l.updateWidth()
+	}
+	return l.layout
+}
+
+// Width returns the width lines are wrapped, ellipsized and aligned to. A value of 0 or less means
+// the width is unlimited.
+func (l *Layout) Width() float64 {
+	return l.width
+}
+
+// SetWidth sets the width lines are wrapped, ellipsized and aligned to. A value of 0 or less means
+// the width is unlimited.
+func (l *Layout) SetWidth(width float64) {
+	if width != l.width {
+		l.width = width
+		l.updateWidth()
+	}
+}
+
+// Wrap returns the wrap mode.
+func (l *Layout) Wrap() Wrap {
+	return l.wrap
+}
+
+// SetWrap sets the wrap mode.
+func (l *Layout) SetWrap(wrap Wrap) {
+	l.wrap = wrap
+	switch wrap {
+	case WrapChar:
+		C.pango_layout_set_wrap(l.layout, C.PANGO_WRAP_CHAR)
+	case WrapWordChar:
+		C.pango_layout_set_wrap(l.layout, C.PANGO_WRAP_WORD_CHAR)
+	default:
+		C.pango_layout_set_wrap(l.layout, C.PANGO_WRAP_WORD)
+	}
+	l.updateWidth()
+}
+
+// Alignment returns the alignment.
+func (l *Layout) Alignment() Alignment {
+	return l.alignment
+}
+
+// SetAlignment sets the alignment.
+func (l *Layout) SetAlignment(alignment Alignment) {
+	l.alignment = alignment
+	C.pango_layout_set_justify(l.layout, C.FALSE)
+	switch alignment {
+	case AlignCenter:
+		C.pango_layout_set_alignment(l.layout, C.PANGO_ALIGN_CENTER)
+	case AlignRight:
+		C.pango_layout_set_alignment(l.layout, C.PANGO_ALIGN_RIGHT)
+	case AlignJustified:
+		C.pango_layout_set_alignment(l.layout, C.PANGO_ALIGN_LEFT)
+		C.pango_layout_set_justify(l.layout, C.TRUE)
+	default:
+		C.pango_layout_set_alignment(l.layout, C.PANGO_ALIGN_LEFT)
+	}
+}
+
+// Ellipsize returns the ellipsize mode.
+func (l *Layout) Ellipsize() Ellipsize {
+	return l.ellipsize
+}
+
+// SetEllipsize sets the ellipsize mode. Ellipsizing only takes effect when the width is limited.
+func (l *Layout) SetEllipsize(ellipsize Ellipsize) {
+	l.ellipsize = ellipsize
+	switch ellipsize {
+	case EllipsizeStart:
+		C.pango_layout_set_ellipsize(l.layout, C.PANGO_ELLIPSIZE_START)
+	case EllipsizeMiddle:
+		C.pango_layout_set_ellipsize(l.layout, C.PANGO_ELLIPSIZE_MIDDLE)
+	case EllipsizeEnd:
+		C.pango_layout_set_ellipsize(l.layout, C.PANGO_ELLIPSIZE_END)
+	default:
+		C.pango_layout_set_ellipsize(l.layout, C.PANGO_ELLIPSIZE_NONE)
+	}
+	l.updateWidth()
+}
+
+// Direction returns the base direction.
+func (l *Layout) Direction() Direction {
+	return l.direction
+}
+
+// SetDirection sets the base direction, which determines the order of text runs that have no
+// strong direction of their own, as well as which edge is the start of each line.
+func (l *Layout) SetDirection(direction Direction) {
+	l.direction = direction
+	context := C.pango_layout_get_context(l.layout)
+	switch direction {
+	case DirectionLeftToRight:
+		C.pango_layout_set_auto_dir(l.layout, C.FALSE)
+		C.pango_context_set_base_dir(context, C.PANGO_DIRECTION_LTR)
+	case DirectionRightToLeft:
+		C.pango_layout_set_auto_dir(l.layout, C.FALSE)
+		C.pango_context_set_base_dir(context, C.PANGO_DIRECTION_RTL)
+	default:
+		C.pango_layout_set_auto_dir(l.layout, C.TRUE)
+		C.pango_context_set_base_dir(context, C.PANGO_DIRECTION_LTR)
+	}
+	C.pango_layout_context_changed(l.layout)
+	l.naturalValid = false
+	l.updateWidth()
+}
+
+// RightToLeft returns true if the resolved base direction is right-to-left. When the direction
+// is DirectionAuto, this is determined by the first character with a strong direction.
+func (l *Layout) RightToLeft() bool {
+	switch l.direction {
+	case DirectionLeftToRight:
+		return false
+	case DirectionRightToLeft:
+		return true
+	default:
+		cstr := C.CString(l.text)
+		defer C.g_free(C.gpointer(cstr))
+		return C.pango_find_base_dir(cstr, -1) == C.PANGO_DIRECTION_RTL
+	}
+}
+
+// LineSpacing returns the amount of space between lines.
+func (l *Layout) LineSpacing() float64 {
+	return l.lineSpacing
+}
+
+// SetLineSpacing sets the amount of space between lines. By default, this is the font's leading.
+func (l *Layout) SetLineSpacing(spacing float64) {
+	l.leadingSpacing = false
+	l.setLineSpacing(spacing)
+}
+
+func (l *Layout) setLineSpacing(spacing float64) {
+	l.lineSpacing = spacing
+	C.pango_layout_set_spacing(l.layout, C.int(spacing*font.PangoScale))
+}
+
+// MaxLines returns the maximum number of lines that will be shown. A value of 0 or less means
+// there is no limit.
+func (l *Layout) MaxLines() int {
+	return l.maxLines
+}
+
+// SetMaxLines sets the maximum number of lines that will be shown. A value of 0 or less means
+// there is no limit. When ellipsizing, the last line shown is ellipsized. Otherwise, any
+// further lines are simply omitted.
+func (l *Layout) SetMaxLines(maxLines int) {
+	l.maxLines = maxLines
+	if maxLines > 0 {
+		C.pango_layout_set_height(l.layout, C.int(-maxLines))
+	} else {
+		C.pango_layout_set_height(l.layout, -1)
+	}
+}
+
+func (l *Layout) updateWidth() {
+	width := C.int(-1)
+	if l.width > 0 {
+		width = C.int(l.width * font.PangoScale)
+		if l.wrap == WrapNone && l.ellipsize == EllipsizeNone {
+			// Pango always wraps to the width when one is set, so widen it as needed to hold
+			// the longest line while still allowing the lines to be aligned.
+			if natural := l.naturalWidth(); natural > width {
+				width = natural
+			}
+		}
+	}
+	C.pango_layout_set_width(l.layout, width)
+}
+
+// naturalWidth returns the width of the longest line when the text isn't wrapped. As measuring it
+// requires shaping the text without a width, it is only measured again after something that
+// affects it has changed.
+func (l *Layout) naturalWidth() C.int {
+	if !l.naturalValid {
+		C.pango_layout_set_width(l.layout, -1)
+		C.pango_layout_get_size(l.layout, &l.natural, nil)
+		l.naturalValid = true
+	}
+	return l.natural
+}
+
+// LineCount returns the number of lines that will be shown.
+func (l *Layout) LineCount() int {
+	count := int(C.pango_layout_get_line_count(l.shaped()))
+	if l.maxLines > 0 && count > l.maxLines {
+		count = l.maxLines
+	}
+	return count
+}
+
+// Lines returns the metrics of each line that will be shown.
+func (l *Layout) Lines() []LineMetrics {
+	count := l.LineCount()
+	lines := make([]LineMetrics, 0, count)
+	top := l.font.Leading()
+	iter := C.pango_layout_get_iter(l.shaped())
+	for len(lines) < count {
+		line := C.pango_layout_iter_get_line_readonly(iter)
+		var logical C.PangoRectangle
+		C.pango_layout_iter_get_line_extents(iter, nil, &logical)
+		start := runeIndex(l.text, int(line.start_index))
+		lines = append(lines, LineMetrics{
+			Bounds:   fromPangoRect(logical, top),
+			Baseline: top + float64(C.pango_layout_iter_get_baseline(iter))/font.PangoScale,
+			Start:    start,
+			Length:   runeIndex(l.text, int(line.start_index+line.length)) - start,
+		})
+		if C.pango_layout_iter_next_line(iter) == C.FALSE {
+			break
+		}
+	}
+	C.pango_layout_iter_free(iter)
+	return lines
+}
+
+// Extents returns the logical bounds of the lines that will be shown.
+func (l *Layout) Extents() geom.Rect {
+	var extents geom.Rect
+	for i, line := range l.Lines() {
+		if i == 0 {
+			extents = line.Bounds
+		} else {
+			extents.Union(line.Bounds)
+		}
+	}
+	extents.Height += extents.Y
+	extents.Y = 0
+	return extents
+}
+
+// Size returns the size of the lines that will be shown, including the leading above the first
+// line.
+func (l *Layout) Size() geom.Size {
+	return l.Extents().Size
+}
+
+// IndexForPosition returns the rune index of the character boundary closest to 'pt'.
+func (l *Layout) IndexForPosition(pt geom.Point) int {
+	var index, trailing C.int
+	C.pango_layout_xy_to_index(l.shaped(), C.int(pt.X*font.PangoScale), C.int((pt.Y-l.font.Leading())*font.PangoScale), &index, &trailing)
+	return runeIndex(l.text, int(index)) + int(trailing)
+}
+
+// LinkAt returns the target of the link at 'pt', or an empty string if there isn't one.
+func (l *Layout) LinkAt(pt geom.Point) string {
+	if l.attributed == nil {
+		return ""
+	}
+	var index, trailing C.int
+	if C.pango_layout_xy_to_index(l.shaped(), C.int(pt.X*font.PangoScale), C.int((pt.Y-l.font.Leading())*font.PangoScale), &index, &trailing) == C.FALSE {
+		return ""
+	}
+	return l.attributed.LinkAt(runeIndex(l.text, int(index)))
+}
+
+// PositionForIndex returns the bounds of the character at rune index 'index'. For an index at the
+// end of the text, the width of the returned bounds is 0.
+func (l *Layout) PositionForIndex(index int) geom.Rect {
+	var pos C.PangoRectangle
+	C.pango_layout_index_to_pos(l.shaped(), C.int(byteIndex(l.text, index)), &pos)
+	return fromPangoRect(pos, l.font.Leading())
+}
+
+// LineForIndex returns the line containing the rune index 'index'.
+func (l *Layout) LineForIndex(index int) int {
+	var line C.int
+	C.pango_layout_index_to_line_x(l.shaped(), C.int(byteIndex(l.text, index)), C.FALSE, &line, nil)
+	return int(line)
+}
+
+// Draw the Layout with its origin at 'x', 'y', using the current fill color.
+func (l *Layout) Draw(gc *draw.Graphics, x, y float64) {
+	cr := (*C.cairo_t)(unsafe.Pointer(gc.CairoContext()))
+	// The desktop's font rendering options may have changed since the layout was created, and the
+	// text must be shaped and hinted for the target's transformation and font options, just as
+	// Graphics.DrawString() does.
+	context := C.pango_layout_get_context(l.shaped())
+	font.ConfigurePangoContext(unsafe.Pointer(context))
+	C.pango_cairo_update_layout(cr, l.layout)
+	if serial := C.pango_context_get_serial(context); serial != l.contextSerial {
+		l.contextSerial = serial
+		l.naturalValid = false
+		l.updateWidth()
+	}
+	gc.Save()
+	if int(C.pango_layout_get_line_count(l.layout)) > l.LineCount() {
+		extents := l.Extents()
+		extents.X += x
+		extents.Y += y
+		gc.Rect(extents)
+		gc.Clip()
+	}
+	gc.MoveTo(x, y+l.font.Leading())
+	C.pango_cairo_show_layout(cr, l.layout)
+	gc.Restore()
+}
+
+func fromPangoRect(rect C.PangoRectangle, top float64) geom.Rect {
+	return geom.Rect{Point: geom.Point{X: float64(rect.x) / font.PangoScale, Y: top + float64(rect.y)/font.PangoScale}, Size: geom.Size{Width: float64(rect.width) / font.PangoScale, Height: float64(rect.height) / font.PangoScale}}
+}
+
+func runeIndex(text string, byteIndex int) int {
+	if byteIndex < 0 {
+		byteIndex = 0
+	} else if byteIndex > len(text) {
+		byteIndex = len(text)
+	}
+	return utf8.RuneCountInString(text[:byteIndex])
+}
+
+func byteIndex(text string, runeIndex int) int {
+	for i := range text {
+		if runeIndex <= 0 {
+			return i
+		}
+		runeIndex--
+	}
+	return len(text)
+}
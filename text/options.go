@@ -0,0 +1,54 @@
+package text
+
+// Possible wrap modes.
+const (
+	// WrapNone doesn't wrap lines. Only explicit line breaks start a new line.
+	WrapNone Wrap = iota
+	// WrapWord wraps lines at word boundaries.
+	WrapWord
+	// WrapChar wraps lines at character boundaries.
+	WrapChar
+	// WrapWordChar wraps lines at word boundaries, falling back to character boundaries if a
+	// word doesn't fit on a line by itself.
+	WrapWordChar
+)
+
+// Wrap specifies how lines are broken when they exceed the width of a Layout.
+type Wrap uint8
+
+// Possible alignments.
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+	// AlignJustified stretches the spacing of each wrapped line to fill the width of the Layout.
+	// The last line of each paragraph is aligned to the left.
+	AlignJustified
+)
+
+// Alignment specifies how lines are positioned horizontally within the width of a Layout.
+type Alignment uint8
+
+// Possible ellipsize modes.
+const (
+	EllipsizeNone Ellipsize = iota
+	EllipsizeStart
+	EllipsizeMiddle
+	EllipsizeEnd
+)
+
+// Ellipsize specifies where text is replaced by an ellipsis when it doesn't fit within the width
+// of a Layout.
+type Ellipsize uint8
+
+// Possible directions.
+const (
+	// DirectionAuto determines the base direction from the first character with a strong
+	// direction, falling back to left-to-right.
+	DirectionAuto Direction = iota
+	DirectionLeftToRight
+	DirectionRightToLeft
+)
+
+// Direction specifies the base direction of the text in a Layout.
+type Direction uint8
@@ -0,0 +1,105 @@
+package uitest
+
+import (
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+// The largest possible value returned by pixelDelta.
+const maxPixelDelta = 35215
+
+// Tolerance controls how closely two images must match to be considered the same.
+type Tolerance struct {
+	// Threshold is the perceptual difference, from 0 to 1, at which two pixels are considered to
+	// differ. Smaller values are more strict.
+	Threshold float64
+	// MaxDiffPixels is the number of differing pixels allowed.
+	MaxDiffPixels int
+	// MaxDiffRatio is the fraction, from 0 to 1, of differing pixels allowed. Used in addition to
+	// MaxDiffPixels.
+	MaxDiffRatio float64
+}
+
+// DefaultTolerance ignores the subtle differences produced by anti-aliasing and font rendering
+// while still catching most layout and paint changes.
+var DefaultTolerance = Tolerance{Threshold: 0.1}
+
+// Difference holds the result of comparing two images.
+type Difference struct {
+	// Pixels is the number of pixels that differ by more than the Tolerance's Threshold.
+	Pixels int
+	// SizeMismatch is true if the images have different dimensions.
+	SizeMismatch bool
+	// Image highlights the pixels that differ in red atop a faded copy of the expected image. Nil
+	// if the sizes differ.
+	Image *draw.ImageData
+}
+
+// Compare the 'actual' image to the 'expected' image.
+func Compare(expected, actual *draw.ImageData, tolerance Tolerance) *Difference {
+	if expected.Width != actual.Width || expected.Height != actual.Height {
+		return &Difference{SizeMismatch: true}
+	}
+	diff := &Difference{Image: &draw.ImageData{Width: expected.Width, Height: expected.Height, Pixels: make([]color.Color, len(expected.Pixels))}}
+	limit := maxPixelDelta * tolerance.Threshold * tolerance.Threshold
+	for i, one := range expected.Pixels {
+		if pixelDelta(one, actual.Pixels[i]) > limit {
+			diff.Pixels++
+			diff.Image.Pixels[i] = color.Red
+		} else {
+			gray := fadedGray(one)
+			diff.Image.Pixels[i] = color.RGB(gray, gray, gray)
+		}
+	}
+	return diff
+}
+
+// Within returns true if the difference is acceptable for the specified tolerance.
+func (diff *Difference) Within(tolerance Tolerance) bool {
+	if diff.SizeMismatch {
+		return false
+	}
+	if diff.Pixels <= tolerance.MaxDiffPixels {
+		return true
+	}
+	return diff.Image != nil && float64(diff.Pixels) <= tolerance.MaxDiffRatio*float64(len(diff.Image.Pixels))
+}
+
+// pixelDelta returns the squared perceptual distance between two colors, as measured in the YIQ
+// color space after compositing each onto white.
+func pixelDelta(c1, c2 color.Color) float64 {
+	if c1 == c2 {
+		return 0
+	}
+	r1, g1, b1 := compositeOnWhite(c1)
+	r2, g2, b2 := compositeOnWhite(c2)
+	y := rgbToY(r1, g1, b1) - rgbToY(r2, g2, b2)
+	i := rgbToI(r1, g1, b1) - rgbToI(r2, g2, b2)
+	q := rgbToQ(r1, g1, b1) - rgbToQ(r2, g2, b2)
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+func compositeOnWhite(c color.Color) (r, g, b float64) {
+	return blendWithWhite(float64(c.Red()), c), blendWithWhite(float64(c.Green()), c), blendWithWhite(float64(c.Blue()), c)
+}
+
+func blendWithWhite(value float64, c color.Color) float64 {
+	return 255 + (value-255)*c.AlphaIntensity()
+}
+
+func fadedGray(c color.Color) int {
+	r, g, b := compositeOnWhite(c)
+	return int(255 - 0.1*(255-rgbToY(r, g, b)))
+}
+
+func rgbToY(r, g, b float64) float64 {
+	return r*0.29889531 + g*0.58662247 + b*0.11448223
+}
+
+func rgbToI(r, g, b float64) float64 {
+	return r*0.59597799 - g*0.27417610 - b*0.32180189
+}
+
+func rgbToQ(r, g, b float64) float64 {
+	return r*0.21147017 - g*0.52261711 + b*0.31114694
+}
@@ -0,0 +1,132 @@
+package uitest
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+var (
+	// GoldenDir holds the directory, relative to the working directory of the test, in which golden
+	// images are stored.
+	GoldenDir = filepath.Join("testdata", "golden")
+	// Update causes golden images to be written rather than compared. It is initially set to true
+	// if the UITEST_UPDATE environment variable is set to a non-empty value.
+	Update = os.Getenv("UITEST_UPDATE") != ""
+)
+
+// T is the subset of testing.TB used to report failures.
+type T interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CheckWidget renders the widget at the specified size and compares the result against the golden
+// image with the given name. Returns true if the images match.
+func CheckWidget(t T, name string, w ui.Widget, size geom.Size, tolerance Tolerance) bool {
+	t.Helper()
+	actual, err := Render(w, size)
+	if err != nil {
+		t.Errorf("unable to render %s: %v", name, err)
+		return false
+	}
+	return CheckImage(t, name, actual, tolerance)
+}
+
+// CheckImage compares the image against the golden image with the given name. Returns true if the
+// images match. On failure, the actual image and an image highlighting the differences are
+// written next to the golden image. When Update is true, the golden image is replaced instead.
+func CheckImage(t T, name string, actual *draw.ImageData, tolerance Tolerance) bool {
+	t.Helper()
+	goldenPath := filepath.Join(GoldenDir, name+".png")
+	actualPath := filepath.Join(GoldenDir, name+".actual.png")
+	diffPath := filepath.Join(GoldenDir, name+".diff.png")
+	if Update {
+		if err := WritePNG(goldenPath, actual); err != nil {
+			t.Errorf("unable to update golden image %s: %v", goldenPath, err)
+			return false
+		}
+		os.Remove(actualPath)
+		os.Remove(diffPath)
+		return true
+	}
+	expected, err := ReadPNG(goldenPath)
+	if err != nil {
+		t.Errorf("unable to load golden image %s (set UITEST_UPDATE=1 to create it): %v", goldenPath, err)
+		WritePNG(actualPath, actual)
+		return false
+	}
+	diff := Compare(expected, actual, tolerance)
+	if diff.Within(tolerance) {
+		os.Remove(actualPath)
+		os.Remove(diffPath)
+		return true
+	}
+	WritePNG(actualPath, actual)
+	if diff.SizeMismatch {
+		t.Errorf("%s: image size %dx%d does not match golden image size %dx%d; actual image written to %s", name, actual.Width, actual.Height, expected.Width, expected.Height, actualPath)
+		return false
+	}
+	WritePNG(diffPath, diff.Image)
+	t.Errorf("%s: %d of %d pixels differ from the golden image; see %s and %s", name, diff.Pixels, len(actual.Pixels), actualPath, diffPath)
+	return false
+}
+
+// ReadPNG loads a PNG image from a file.
+func ReadPNG(path string) (*draw.ImageData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return imageDataFrom(img), nil
+}
+
+// WritePNG saves an image to a file as a PNG, creating any missing parent directories.
+func WritePNG(path string, img *draw.ImageData) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errs.Wrap(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if err = png.Encode(f, img); err != nil {
+		f.Close()
+		return errs.Wrap(err)
+	}
+	if err = f.Close(); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func imageDataFrom(img image.Image) *draw.ImageData {
+	bounds := img.Bounds()
+	data := &draw.ImageData{Width: bounds.Dx(), Height: bounds.Dy(), Pixels: make([]color.Color, bounds.Dx()*bounds.Dy())}
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a != 0 {
+				r = r * 0xffff / a
+				g = g * 0xffff / a
+				b = b * 0xffff / a
+			}
+			data.Pixels[i] = color.Color((a>>8)<<24 | (r>>8)<<16 | (g>>8)<<8 | b>>8)
+			i++
+		}
+	}
+	return data
+}
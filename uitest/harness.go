@@ -0,0 +1,171 @@
+//go:build headless
+// +build headless
+
+package uitest
+
+import (
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/app"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/event/button"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/window"
+)
+
+var startOnce sync.Once
+
+// Harness hosts a widget in a headless window so that it can be driven by simulated input and
+// compared against golden images. The goroutine that creates a Harness becomes the UI thread, so
+// all of its methods must be called from that goroutine. As the testing package runs each test
+// on its own goroutine, harnesses from different tests must not be used at the same time.
+type Harness struct {
+	Window *window.Window
+	Widget ui.Widget
+}
+
+// NewHarness places the widget into a new headless window whose content is exactly the specified
+// size, then processes any pending events.
+func NewHarness(w ui.Widget, size geom.Size) *Harness {
+	startOnce.Do(func() {
+		app.EventHandlers().Add(event.AppLastWindowClosedType, func(evt event.Event) {
+			evt.(*event.AppLastWindowClosed).RemainOpen()
+		})
+		app.StartHeadless()
+	})
+	app.BindUIThread()
+	wnd := window.NewWindowWithContentSize(geom.Point{}, size, window.BorderlessWindowMask)
+	wnd.Content().AddChild(w)
+	w.SetBounds(geom.Rect{Size: size})
+	wnd.ToFront()
+	h := &Harness{Window: wnd, Widget: w}
+	h.Pump()
+	return h
+}
+
+// Close the harness' window.
+func (h *Harness) Close() {
+	h.Window.Close()
+	h.Pump()
+}
+
+// Pump processes pending tasks and events until none remain.
+func (h *Harness) Pump() {
+	for window.PumpEvents(false) > 0 {
+	}
+}
+
+// Wait processes tasks and events for the specified duration, which allows timer-based tasks,
+// such as animations and tooltips, to run.
+func (h *Harness) Wait(duration time.Duration) {
+	window.PumpEventsFor(duration)
+	h.Pump()
+}
+
+// Snapshot processes pending events, then returns a copy of what the window currently shows.
+func (h *Harness) Snapshot() *draw.ImageData {
+	h.Pump()
+	return h.Window.Snapshot()
+}
+
+// Check compares the current window content against the golden image with the given name. See
+// CheckImage for details.
+func (h *Harness) Check(t T, name string, tolerance Tolerance) bool {
+	t.Helper()
+	return CheckImage(t, name, h.Snapshot(), tolerance)
+}
+
+// Focus sets the keyboard focus to the target widget.
+func (h *Harness) Focus(target ui.Widget) {
+	h.Window.SetFocus(target)
+	h.Pump()
+}
+
+// MoveMouse moves the mouse to 'where', which is in the target's local coordinates.
+func (h *Harness) MoveMouse(target ui.Widget, where geom.Point) {
+	h.Window.InjectMouseMoved(target.ToWindow(where), 0)
+	h.Pump()
+}
+
+// Click performs a left mouse button click at 'where', which is in the target's local
+// coordinates.
+func (h *Harness) Click(target ui.Widget, where geom.Point, keyModifiers keys.Modifiers) {
+	h.MultiClick(target, where, 1, keyModifiers)
+}
+
+// ClickCenter performs a left mouse button click in the center of the target.
+func (h *Harness) ClickCenter(target ui.Widget) {
+	h.Click(target, centerOf(target), 0)
+}
+
+// MultiClick performs a series of left mouse button clicks at 'where', which is in the target's
+// local coordinates, such that the last click reports a click count of 'count'.
+func (h *Harness) MultiClick(target ui.Widget, where geom.Point, count int, keyModifiers keys.Modifiers) {
+	pt := target.ToWindow(where)
+	h.Window.InjectMouseMoved(pt, keyModifiers)
+	for i := 1; i <= count; i++ {
+		h.Window.InjectMouseDown(pt, button.Left, i, keyModifiers)
+		h.Window.InjectMouseUp(pt, button.Left, keyModifiers)
+	}
+	h.Pump()
+}
+
+// Drag presses the left mouse button at 'from', moves it to 'to' in 'steps' increments, then
+// releases it. Both points are in the target's local coordinates.
+func (h *Harness) Drag(target ui.Widget, from, to geom.Point, steps int, keyModifiers keys.Modifiers) {
+	if steps < 1 {
+		steps = 1
+	}
+	start := target.ToWindow(from)
+	end := target.ToWindow(to)
+	h.Window.InjectMouseMoved(start, keyModifiers)
+	h.Window.InjectMouseDown(start, button.Left, 1, keyModifiers)
+	for i := 1; i <= steps; i++ {
+		fraction := float64(i) / float64(steps)
+		pt := geom.Point{X: start.X + (end.X-start.X)*fraction, Y: start.Y + (end.Y-start.Y)*fraction}
+		h.Window.InjectMouseDragged(pt, button.Left, keyModifiers)
+	}
+	h.Window.InjectMouseUp(end, button.Left, keyModifiers)
+	h.Pump()
+}
+
+// KeyPress sends a key down followed by a key up to the focused widget.
+func (h *Harness) KeyPress(keyCode int, ch rune, keyModifiers keys.Modifiers) {
+	h.Window.InjectKeyDown(keyCode, ch, keyModifiers, false)
+	h.Window.InjectKeyUp(keyCode, keyModifiers)
+	h.Pump()
+}
+
+// Type sends a key press to the focused widget for each character in the text.
+func (h *Harness) Type(text string) {
+	for _, ch := range text {
+		keyCode, keyModifiers := keyCodeForRune(ch)
+		h.Window.InjectKeyDown(keyCode, ch, keyModifiers, false)
+		h.Window.InjectKeyUp(keyCode, keyModifiers)
+	}
+	h.Pump()
+}
+
+func keyCodeForRune(ch rune) (keyCode int, keyModifiers keys.Modifiers) {
+	if ch > unicode.MaxASCII {
+		return 0, 0
+	}
+	if unicode.IsUpper(ch) {
+		keyModifiers = keys.ShiftModifier
+	}
+	keyCode = int(unicode.ToUpper(ch))
+	if keys.MappingForKeyCode(keyCode) == nil {
+		return 0, keyModifiers
+	}
+	return keyCode, keyModifiers
+}
+
+func centerOf(target ui.Widget) geom.Point {
+	bounds := target.LocalBounds()
+	return geom.Point{X: bounds.X + bounds.Width/2, Y: bounds.Y + bounds.Height/2}
+}
@@ -0,0 +1,49 @@
+//go:build headless
+// +build headless
+
+package uitest
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/app"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/widget"
+)
+
+var blockSize = geom.Size{Width: 40, Height: 30}
+
+func newBlock() *widget.Block {
+	block := widget.NewBlock()
+	block.SetBackground(color.RGB(0, 128, 255))
+	block.SetBorder(border.NewLine(color.Black, geom.NewUniformInsets(2)))
+	return block
+}
+
+func TestRenderGolden(t *testing.T) {
+	CheckWidget(t, "block", newBlock(), blockSize, DefaultTolerance)
+}
+
+func TestHarnessGolden(t *testing.T) {
+	h := NewHarness(newBlock(), blockSize)
+	defer h.Close()
+	h.Check(t, "block", DefaultTolerance)
+}
+
+// TestHarnessUIThread runs after the tests above, on a different goroutine, so it verifies that
+// each Harness takes over the UI thread and that invoked tasks are processed.
+func TestHarnessUIThread(t *testing.T) {
+	h := NewHarness(newBlock(), blockSize)
+	defer h.Close()
+	if !app.OnUIThread() {
+		t.Fatal("the goroutine that created the harness should be the UI thread")
+	}
+	ran := false
+	app.Invoke(func() { ran = app.OnUIThread() })
+	h.Pump()
+	if !ran {
+		t.Error("invoked task did not run on the UI thread")
+	}
+}
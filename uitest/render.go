@@ -0,0 +1,27 @@
+package uitest
+
+import (
+	"bytes"
+	"image/png"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// Render lays out the widget at the specified size and paints it, along with its children, onto
+// an offscreen image.
+func Render(w ui.Widget, size geom.Size) (*draw.ImageData, error) {
+	w.SetSize(size)
+	var buffer bytes.Buffer
+	if err := widget.RenderTo(w, widget.PNG, &buffer, 1); err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return imageDataFrom(img), nil
+}
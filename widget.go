@@ -136,6 +136,14 @@ type Widget interface {
 	Background() color.Color
 	// SetBackground sets the background color of this widget.
 	SetBackground(color color.Color)
+
+	// ScrollIntoView attempts to scroll this widget into view by adjusting any
+	// enclosing scroll areas.
+	ScrollIntoView()
+	// ScrollRectIntoView attempts to scroll the area 'rect', in local
+	// coordinates, into view by adjusting any enclosing scroll areas, moving
+	// each as little as possible.
+	ScrollRectIntoView(rect geom.Rect)
 }
 
 // Sizes returns the minimum, preferred, and maximum sizes the 'widget' wishes
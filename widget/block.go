@@ -27,6 +27,8 @@ type Block struct {
 	bounds        geom.Rect
 	layoutData    interface{}
 	background    color.Color
+	backgroundImg *draw.NineSlice
+	layer         *layer
 	needLayout    bool
 	disabled      bool
 	focusable     bool
@@ -140,6 +142,9 @@ func (b *Block) Repaint() {
 func (b *Block) RepaintBounds(bounds geom.Rect) {
 	bounds.Intersect(b.LocalBounds())
 	if !bounds.IsEmpty() {
+		if b.layer != nil {
+			bounds = b.layer.invalidate(bounds)
+		}
 		if p := b.Parent(); p != nil {
 			bounds.X += b.bounds.X
 			bounds.Y += b.bounds.Y
@@ -152,16 +157,32 @@ func (b *Block) RepaintBounds(bounds geom.Rect) {
 
 // Paint implements the Widget interface.
 func (b *Block) Paint(gc *draw.Graphics, dirty geom.Rect) {
+	if b.layer != nil {
+		b.paintLayer(gc, dirty)
+	} else {
+		b.paintContent(gc, dirty)
+	}
+}
+
+func (b *Block) paintContent(gc *draw.Graphics, dirty geom.Rect) {
 	dirty.Intersect(b.LocalBounds())
 	if !dirty.IsEmpty() {
 		gc.Save()
 		gc.Rect(dirty)
 		gc.Clip()
+		if shaped, ok := b.Border().(border.Shaped); ok {
+			gc.BeginPath()
+			gc.AddPath(shaped.Shape(b.LocalBounds()))
+			gc.Clip()
+		}
 		gc.Save()
 		if b.background.Alpha() > 0 {
 			gc.SetColor(b.background)
 			gc.FillRect(dirty)
 		}
+		if b.backgroundImg != nil {
+			b.backgroundImg.Draw(gc, b.LocalBounds())
+		}
 		event.Dispatch(event.NewPaint(b, gc, dirty))
 		gc.Restore()
 		for _, child := range b.children {
@@ -299,9 +320,13 @@ func (b *Block) Parent() ui.Widget {
 	return b.parent
 }
 
-// SetParent implements the Widget interface.
+// SetParent implements the Widget interface. When the block is removed from its parent, any cached
+// layers within it are released, as it is no longer part of a window.
 func (b *Block) SetParent(parent ui.Widget) {
 	b.parent = parent
+	if parent == nil {
+		ReleaseLayers(b)
+	}
 }
 
 // Window implements the Widget interface.
@@ -443,7 +468,43 @@ func (b *Block) SetBackground(color color.Color) {
 	}
 }
 
-// ScrollIntoView attempts to scroll the block into view.
+// BackgroundImage returns the nine-slice image drawn over the background color, if any.
+func (b *Block) BackgroundImage() *draw.NineSlice {
+	return b.backgroundImg
+}
+
+// SetBackgroundImage sets a nine-slice image to be stretched over the block's bounds, on top of
+// the background color. Pass nil to remove it.
+func (b *Block) SetBackgroundImage(img *draw.NineSlice) {
+	if img != b.backgroundImg {
+		b.backgroundImg = img
+		b.Repaint()
+	}
+}
+
+// ScrollIntoView implements the Widget interface.
 func (b *Block) ScrollIntoView() {
-	// RAW: Implement
+	b.ScrollRectIntoView(b.LocalBounds())
+}
+
+// ScrollRectIntoView implements the Widget interface.
+func (b *Block) ScrollRectIntoView(rect geom.Rect) {
+	rect.Point = b.ToWindow(rect.Point)
+	for parent := b.parent; parent != nil; parent = parent.Parent() {
+		if sc, ok := parent.(scrollContainer); ok {
+			if content := sc.Content(); content != nil {
+				local := rect
+				local.Point = content.FromWindow(local.Point)
+				scrollToReveal(sc, true, local.X, local.Width)
+				scrollToReveal(sc, false, local.Y, local.Height)
+				parent.Repaint()
+				rect.Point = content.ToWindow(local.Point)
+				// Only the portion within the viewport, which excludes any scroll bars, can be
+				// revealed by scroll containers further up the hierarchy.
+				visible := geom.Rect{Point: geom.Point{X: sc.ScrolledPosition(true), Y: sc.ScrolledPosition(false)}, Size: geom.Size{Width: sc.VisibleSize(true), Height: sc.VisibleSize(false)}}
+				visible.Point = content.ToWindow(visible.Point)
+				rect.Intersect(visible)
+			}
+		}
+	}
 }
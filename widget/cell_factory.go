@@ -15,3 +15,11 @@ type CellFactory interface {
 	// selected state. 'focused' indicates the cell should be created in its focused state.
 	CreateCell(owner ui.Widget, element interface{}, index int, selected, focused bool) ui.Widget
 }
+
+// Searchable defines the method a CellFactory may implement to allow the content of its cells to be
+// searched.
+type Searchable interface {
+	// CellText returns the text displayed by the cell for 'element'. 'index' indicates which row
+	// the element came from.
+	CellText(element interface{}, index int) string
+}
@@ -0,0 +1,277 @@
+package findbar
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/find"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/button"
+	"github.com/richardwilkes/ui/widget/checkbox"
+	"github.com/richardwilkes/ui/widget/textfield"
+)
+
+// FindBar provides the standard controls for searching a find.Findable target: a field for the
+// text to search for, options for matching case, whole words and regular expressions, and buttons
+// for moving to the previous and next matches. While the bar is in use, all matches within the
+// target are highlighted.
+type FindBar struct {
+	widget.Block
+	field     *textfield.TextField
+	matchCase *checkbox.CheckBox
+	wholeWord *checkbox.CheckBox
+	regex     *checkbox.CheckBox
+	target    find.Findable
+	matcher   *find.Matcher
+}
+
+// New creates a new FindBar that searches 'target', which may be nil.
+func New(target find.Findable) *FindBar {
+	bar := &FindBar{target: target}
+	bar.InitTypeAndID(bar)
+	bar.Describer = func() string { return fmt.Sprintf("FindBar #%d", bar.ID()) }
+	bar.SetBackground(color.Background)
+	bar.SetBorder(border.NewEmpty(geom.Insets{Top: 4, Left: 8, Bottom: 4, Right: 8}))
+	lay := flex.NewLayout(bar)
+	lay.Columns = 7
+	lay.HSpacing = 8
+
+	bar.field = textfield.New()
+	bar.field.SetWatermark(i18n.Text("Find"))
+	flexData := flex.NewData()
+	flexData.HAlign = align.Fill
+	flexData.HGrab = true
+	bar.field.SetLayoutData(flexData)
+	handlers := bar.field.EventHandlers()
+	handlers.Add(event.ModifiedType, func(evt event.Event) { bar.update() })
+	handlers.Add(event.ValidateType, bar.validate)
+	bar.AddChild(bar.field)
+
+	bar.matchCase = bar.addCheckBox(i18n.Text("Match Case"))
+	bar.wholeWord = bar.addCheckBox(i18n.Text("Whole Word"))
+	bar.regex = bar.addCheckBox(i18n.Text("Regular Expression"))
+	bar.addButton(i18n.Text("Previous"), func() { bar.FindPrevious() })
+	bar.addButton(i18n.Text("Next"), func() { bar.FindNext() })
+	bar.addButton(i18n.Text("Done"), bar.Done)
+
+	bar.EventHandlers().Add(event.KeyDownType, bar.keyDown)
+	return bar
+}
+
+func (bar *FindBar) addCheckBox(title string) *checkbox.CheckBox {
+	check := checkbox.NewCheckBox(title)
+	check.EventHandlers().Add(event.ClickType, func(evt event.Event) { bar.update() })
+	bar.AddChild(check)
+	return check
+}
+
+func (bar *FindBar) addButton(title string, action func()) {
+	b := button.New(title)
+	b.EventHandlers().Add(event.ClickType, func(evt event.Event) { action() })
+	bar.AddChild(b)
+}
+
+// accessoryHost is implemented by windows that can show a bar across their top, between the menu
+// bar and the content.
+type accessoryHost interface {
+	AccessoryBar() ui.Widget
+	SetAccessoryBar(bar ui.Widget)
+}
+
+// InWindow returns the FindBar shown in the window's accessory bar or, failing that, the first
+// FindBar within the window's content. Returns nil if there is neither.
+func InWindow(wnd ui.Window) *FindBar {
+	if host, ok := wnd.(accessoryHost); ok {
+		if bar, ok := host.AccessoryBar().(*FindBar); ok {
+			return bar
+		}
+	}
+	return findBarIn(wnd.Content())
+}
+
+// CanShow returns true if Show() will return a FindBar for the window.
+func CanShow(wnd ui.Window) bool {
+	if _, ok := wnd.(accessoryHost); ok {
+		return true
+	}
+	return InWindow(wnd) != nil
+}
+
+// Show returns the FindBar in the window. If there isn't one, a new FindBar is created and shown
+// as the window's accessory bar, to be removed again when Done() is called. Returns nil if the
+// window has no FindBar and can't show one.
+func Show(wnd ui.Window) *FindBar {
+	if bar := InWindow(wnd); bar != nil {
+		return bar
+	}
+	host, ok := wnd.(accessoryHost)
+	if !ok {
+		return nil
+	}
+	bar := New(nil)
+	host.SetAccessoryBar(bar)
+	return bar
+}
+
+func findBarIn(w ui.Widget) *FindBar {
+	if bar, ok := w.(*FindBar); ok {
+		return bar
+	}
+	for _, child := range w.Children() {
+		if bar := findBarIn(child); bar != nil {
+			return bar
+		}
+	}
+	return nil
+}
+
+// Target returns the target being searched.
+func (bar *FindBar) Target() find.Findable {
+	return bar.target
+}
+
+// SetTarget sets the target to search. Targets within the bar itself, such as its text field, are
+// ignored.
+func (bar *FindBar) SetTarget(target find.Findable) {
+	if w, ok := target.(ui.Widget); ok && bar.contains(w) {
+		return
+	}
+	if bar.target != target {
+		if bar.target != nil {
+			bar.target.SetFindHighlight(nil)
+		}
+		bar.target = target
+		if bar.target != nil {
+			bar.target.SetFindHighlight(bar.matcher)
+		}
+	}
+}
+
+func (bar *FindBar) contains(w ui.Widget) bool {
+	for ; w != nil; w = w.Parent() {
+		if w == ui.Widget(bar) {
+			return true
+		}
+	}
+	return false
+}
+
+// Options returns the options currently set in the bar.
+func (bar *FindBar) Options() find.Options {
+	return find.Options{
+		Text:      bar.field.Text(),
+		MatchCase: bar.matchCase.State() == checkbox.Checked,
+		WholeWord: bar.wholeWord.State() == checkbox.Checked,
+		Regex:     bar.regex.State() == checkbox.Checked,
+	}
+}
+
+// SetOptions sets the options in the bar.
+func (bar *FindBar) SetOptions(options find.Options) {
+	bar.matchCase.SetState(checkState(options.MatchCase))
+	bar.wholeWord.SetState(checkState(options.WholeWord))
+	bar.regex.SetState(checkState(options.Regex))
+	if !bar.field.SetText(options.Text) {
+		bar.update()
+	}
+}
+
+func checkState(on bool) checkbox.State {
+	if on {
+		return checkbox.Checked
+	}
+	return checkbox.Unchecked
+}
+
+// Activate gives the bar's text field the keyboard focus, with its content selected. If the
+// field is empty, it is first filled in with the options last used for searching.
+func (bar *FindBar) Activate() {
+	if bar.field.Text() == "" {
+		bar.SetOptions(find.LastOptions())
+	}
+	if wnd := bar.Window(); wnd != nil {
+		wnd.SetFocus(bar.field)
+	}
+	bar.field.SelectAll()
+}
+
+// FindNext selects the next match within the target. Returns false if there are no matches.
+func (bar *FindBar) FindNext() bool {
+	if bar.target == nil || bar.matcher == nil {
+		return false
+	}
+	return bar.target.FindNext(bar.matcher)
+}
+
+// FindPrevious selects the previous match within the target. Returns false if there are no
+// matches.
+func (bar *FindBar) FindPrevious() bool {
+	if bar.target == nil || bar.matcher == nil {
+		return false
+	}
+	return bar.target.FindPrevious(bar.matcher)
+}
+
+// Done removes the highlighting from the target and, if the target is a widget, gives it the
+// keyboard focus. A bar shown as the window's accessory bar by Show() is also removed.
+func (bar *FindBar) Done() {
+	wnd := bar.Window()
+	if bar.target != nil {
+		bar.target.SetFindHighlight(nil)
+		if w, ok := bar.target.(ui.Widget); ok {
+			if wnd := w.Window(); wnd != nil {
+				wnd.SetFocus(w)
+			}
+		}
+	}
+	if host, ok := wnd.(accessoryHost); ok && host.AccessoryBar() == bar {
+		host.SetAccessoryBar(nil)
+		if bar.contains(wnd.Focus()) {
+			wnd.FocusNext()
+		}
+	}
+}
+
+func (bar *FindBar) update() {
+	options := bar.Options()
+	var err error
+	if bar.matcher, err = find.NewMatcher(options); err == nil {
+		find.SetLastOptions(options)
+	}
+	if bar.target != nil {
+		bar.target.SetFindHighlight(bar.matcher)
+	}
+}
+
+func (bar *FindBar) validate(evt event.Event) {
+	if options := bar.Options(); options.Text != "" {
+		if _, err := find.NewMatcher(options); err != nil {
+			evt.(*event.Validate).MarkInvalid()
+		}
+	}
+}
+
+func (bar *FindBar) keyDown(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok {
+		switch e.Code() {
+		case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+			if e.Modifiers().ShiftDown() {
+				bar.FindPrevious()
+			} else {
+				bar.FindNext()
+			}
+			evt.Finish()
+		case keys.VirtualKeyEscape:
+			bar.Done()
+			evt.Finish()
+		}
+	}
+}
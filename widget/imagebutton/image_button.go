@@ -18,6 +18,8 @@ type ImageButton struct {
 	Theme         *Theme // The theme the button will use to draw itself.
 	image         *draw.Image
 	disabledImage *draw.Image
+	svg           *draw.SVG
+	svgSize       geom.Size
 	pressed       bool
 }
 
@@ -34,6 +36,30 @@ func NewImageButtonWithImageSize(img *draw.Image, size geom.Size) *ImageButton {
 	button.InitTypeAndID(button)
 	button.disabledImage = img.AcquireDisabled()
 	button.Describer = func() string { return fmt.Sprintf("ImageButton #%d (%v)", button.ID(), button.Image()) }
+	button.initialize(size)
+	return button
+}
+
+// NewSVGImageButton creates a new button with the specified SVG, shown at its intrinsic size.
+func NewSVGImageButton(svg *draw.SVG) *ImageButton {
+	return NewSVGImageButtonWithImageSize(svg, geom.Size{})
+}
+
+// NewSVGImageButtonWithImageSize creates a new button with the specified SVG. The SVG will be
+// rendered at the specified size, using a raster cached for that size. The button itself will be a
+// bit larger, based on the theme settings and border.
+func NewSVGImageButtonWithImageSize(svg *draw.SVG, size geom.Size) *ImageButton {
+	button := &ImageButton{svg: svg, svgSize: size, Theme: StdImageButton}
+	if size.Width <= 0 || size.Height <= 0 {
+		button.svgSize = svg.Size()
+	}
+	button.InitTypeAndID(button)
+	button.Describer = func() string { return fmt.Sprintf("ImageButton #%d (%v)", button.ID(), button.svg) }
+	button.initialize(size)
+	return button
+}
+
+func (button *ImageButton) initialize(size geom.Size) {
 	button.SetFocusable(true)
 	if size.Width <= 0 || size.Height <= 0 {
 		button.SetSizer(button)
@@ -48,12 +74,11 @@ func NewImageButtonWithImageSize(img *draw.Image, size geom.Size) *ImageButton {
 	handlers.Add(event.FocusGainedType, button.focusChanged)
 	handlers.Add(event.FocusLostType, button.focusChanged)
 	handlers.Add(event.KeyDownType, button.keyDown)
-	return button
 }
 
 // Sizes implements Sizer
 func (button *ImageButton) Sizes(hint geom.Size) (min, pref, max geom.Size) {
-	size := button.image.Size()
+	size := button.imageSize()
 	size.Width += button.Theme.HorizontalMargin*2 + 2
 	size.Height += button.Theme.VerticalMargin*2 + 2
 	if border := button.Border(); border != nil {
@@ -93,8 +118,7 @@ func (button *ImageButton) paint(evt event.Event) {
 	bounds.Width -= hSpace
 	bounds.Height -= vSpace
 	if !bounds.IsEmpty() {
-		img := button.CurrentImage()
-		size := img.Size()
+		size := button.imageSize()
 		if size.Width < bounds.Width {
 			bounds.X += (bounds.Width - size.Width) / 2
 			bounds.Width = size.Width
@@ -103,7 +127,9 @@ func (button *ImageButton) paint(evt event.Event) {
 			bounds.Y += (bounds.Height - size.Height) / 2
 			bounds.Height = size.Height
 		}
-		gc.DrawImageInRect(img, bounds)
+		// Rasterize SVGs at device resolution so that they stay sharp on scaled displays
+		scale := gc.DeviceScale()
+		gc.DrawImageInRect(button.imageForSize(geom.Size{Width: bounds.Width * scale, Height: bounds.Height * scale}), bounds)
 	}
 }
 
@@ -154,19 +180,45 @@ func (button *ImageButton) keyDown(evt event.Event) {
 	}
 }
 
-// Image returns this button's base image.
+// Image returns this button's base image. For buttons created from an SVG, this is the raster
+// of the SVG at the button's image size.
 func (button *ImageButton) Image() *draw.Image {
+	if button.svg != nil {
+		return button.svg.Raster(button.svgSize)
+	}
 	return button.image
 }
 
+// SVG returns this button's SVG, or nil if it was created from an image.
+func (button *ImageButton) SVG() *draw.SVG {
+	return button.svg
+}
+
 // CurrentImage returns this button's current image.
 func (button *ImageButton) CurrentImage() *draw.Image {
+	return button.imageForSize(button.imageSize())
+}
+
+func (button *ImageButton) imageForSize(size geom.Size) *draw.Image {
+	if button.svg != nil {
+		if button.Enabled() {
+			return button.svg.Raster(size)
+		}
+		return button.svg.DisabledRaster(size)
+	}
 	if button.Enabled() {
 		return button.image
 	}
 	return button.disabledImage
 }
 
+func (button *ImageButton) imageSize() geom.Size {
+	if button.svg != nil {
+		return button.svgSize
+	}
+	return button.image.Size()
+}
+
 // BaseBackground returns this button's current base background color.
 func (button *ImageButton) BaseBackground() color.Color {
 	switch {
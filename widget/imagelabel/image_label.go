@@ -12,7 +12,9 @@ import (
 // ImageLabel represents a non-interactive image.
 type ImageLabel struct {
 	widget.Block
-	image *draw.Image
+	image   *draw.Image
+	svg     *draw.SVG
+	svgSize geom.Size
 }
 
 // New creates an ImageLabel with the specified image.
@@ -26,18 +28,40 @@ func NewWithSize(img *draw.Image, size geom.Size) *ImageLabel {
 	label := &ImageLabel{image: img}
 	label.InitTypeAndID(label)
 	label.Describer = func() string { return fmt.Sprintf("ImageLabel #%d (%v)", label.ID(), label.image) }
+	label.initialize(size)
+	return label
+}
+
+// NewSVG creates an ImageLabel with the specified SVG, shown at its intrinsic size.
+func NewSVG(svg *draw.SVG) *ImageLabel {
+	return NewSVGWithSize(svg, geom.Size{})
+}
+
+// NewSVGWithSize creates a new ImageLabel with the specified SVG. The SVG will be rendered at the
+// specified size, using a raster cached for that size.
+func NewSVGWithSize(svg *draw.SVG, size geom.Size) *ImageLabel {
+	label := &ImageLabel{svg: svg, svgSize: size}
+	if size.Width <= 0 || size.Height <= 0 {
+		label.svgSize = svg.Size()
+	}
+	label.InitTypeAndID(label)
+	label.Describer = func() string { return fmt.Sprintf("ImageLabel #%d (%v)", label.ID(), label.svg) }
+	label.initialize(size)
+	return label
+}
+
+func (label *ImageLabel) initialize(size geom.Size) {
 	if size.Width <= 0 || size.Height <= 0 {
 		label.SetSizer(label)
 	} else {
 		label.SetSizer(&imageLabelSizer{label: label, size: size})
 	}
 	label.EventHandlers().Add(event.PaintType, label.paint)
-	return label
 }
 
 // Sizes implements Sizer
 func (label *ImageLabel) Sizes(hint geom.Size) (min, pref, max geom.Size) {
-	size := label.image.Size()
+	size := label.naturalSize()
 	if border := label.Border(); border != nil {
 		size.AddInsets(border.Insets())
 	}
@@ -46,7 +70,7 @@ func (label *ImageLabel) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 
 func (label *ImageLabel) paint(evt event.Event) {
 	bounds := label.LocalInsetBounds()
-	size := label.image.Size()
+	size := label.naturalSize()
 	if size.Width < bounds.Width {
 		bounds.X += (bounds.Width - size.Width) / 2
 		bounds.Width = size.Width
@@ -55,7 +79,21 @@ func (label *ImageLabel) paint(evt event.Event) {
 		bounds.Y += (bounds.Height - size.Height) / 2
 		bounds.Height = size.Height
 	}
-	evt.(*event.Paint).GC().DrawImageInRect(label.image, bounds)
+	gc := evt.(*event.Paint).GC()
+	if label.svg != nil {
+		// Rasterize at device resolution so that the result stays sharp on scaled displays
+		scale := gc.DeviceScale()
+		gc.DrawImageInRect(label.svg.Raster(geom.Size{Width: bounds.Width * scale, Height: bounds.Height * scale}), bounds)
+	} else {
+		gc.DrawImageInRect(label.image, bounds)
+	}
+}
+
+func (label *ImageLabel) naturalSize() geom.Size {
+	if label.svg != nil {
+		return label.svgSize
+	}
+	return label.image.Size()
 }
 
 type imageLabelSizer struct {
@@ -2,45 +2,76 @@ package label
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/text"
 	"github.com/richardwilkes/ui/widget"
 )
 
 // Label represents a non-interactive piece of text.
 type Label struct {
 	widget.Block
-	text       string
-	font       *font.Font
+	layout     *text.Layout
 	foreground color.Color
 }
 
 // New creates a label with the specified text.
-func New(text string) *Label {
-	return NewWithFont(text, font.Label)
+func New(str string) *Label {
+	return NewWithFont(str, font.Label)
 }
 
 // NewWithFont creates a label with the specified text and font.
-func NewWithFont(text string, font *font.Font) *Label {
-	label := &Label{text: text, foreground: color.Black, font: font}
+func NewWithFont(str string, font *font.Font) *Label {
+	return newLabel(text.NewLayout(str, font))
+}
+
+// NewAttributed creates a label with the specified attributed string.
+func NewAttributed(str *draw.AttributedString) *Label {
+	return NewAttributedWithFont(str, font.Label)
+}
+
+// NewAttributedWithFont creates a label with the specified attributed string, using 'font' for
+// any text without font attributes.
+func NewAttributedWithFont(str *draw.AttributedString, font *font.Font) *Label {
+	layout := text.NewLayout("", font)
+	layout.SetAttributedString(str)
+	return newLabel(layout)
+}
+
+func newLabel(layout *text.Layout) *Label {
+	label := &Label{layout: layout, foreground: color.Black}
 	label.InitTypeAndID(label)
-	label.Describer = func() string { return fmt.Sprintf("Label #%d (%s)", label.ID(), label.text) }
+	label.Describer = func() string { return fmt.Sprintf("Label #%d (%s)", label.ID(), label.layout.Text()) }
 	label.SetSizer(label)
 	label.EventHandlers().Add(event.PaintType, label.paint)
+	label.EventHandlers().Add(event.SystemFontsChangedType, label.systemFontsChanged)
 	return label
 }
 
+func (label *Label) systemFontsChanged(evt event.Event) {
+	label.layout.SetFont(label.layout.Font())
+}
+
 // Sizes implements Sizer
 func (label *Label) Sizes(hint geom.Size) (min, pref, max geom.Size) {
-	size := label.font.Measure(label.text)
-	size.GrowToInteger()
-	size.ConstrainForHint(hint)
+	var insets geom.Insets
 	if border := label.Border(); border != nil {
-		size.AddInsets(border.Insets())
+		insets = border.Insets()
+	}
+	width := -1.0
+	if label.layout.Wrap() != text.WrapNone && hint.Width > 0 {
+		width = math.Max(hint.Width-(insets.Left+insets.Right), 1)
 	}
+	label.layout.SetWidth(width)
+	size := label.layout.Size()
+	size.GrowToInteger()
+	size.ConstrainForHint(hint)
+	size.AddInsets(insets)
 	return size, size, size
 }
 
@@ -48,8 +79,82 @@ func (label *Label) paint(evt event.Event) {
 	bounds := label.LocalInsetBounds()
 	gc := evt.(*event.Paint).GC()
 	gc.SetColor(label.foreground)
-	size := label.font.Measure(label.text)
-	gc.DrawString(bounds.X, bounds.Y+(bounds.Height-size.Height)/2, label.text, label.font)
+	label.layout.SetWidth(bounds.Width)
+	size := label.layout.Size()
+	label.layout.Draw(gc, bounds.X, bounds.Y+(bounds.Height-size.Height)/2)
+}
+
+// Text returns the text.
+func (label *Label) Text() string {
+	return label.layout.Text()
+}
+
+// SetText sets the text, removing any attributes.
+func (label *Label) SetText(str string) {
+	if label.layout.Text() != str || label.layout.AttributedString() != nil {
+		label.layout.SetText(str)
+		label.SetNeedLayout(true)
+		label.Repaint()
+	}
+}
+
+// AttributedString returns the attributed string, or nil if the text has no attributes.
+func (label *Label) AttributedString() *draw.AttributedString {
+	return label.layout.AttributedString()
+}
+
+// SetAttributedString sets the text and the attributes that style it.
+func (label *Label) SetAttributedString(str *draw.AttributedString) {
+	label.layout.SetAttributedString(str)
+	label.SetNeedLayout(true)
+	label.Repaint()
+}
+
+// LinkAt returns the target of the link at 'pt', in local coordinates, or an empty string if
+// there isn't one.
+func (label *Label) LinkAt(pt geom.Point) string {
+	bounds := label.LocalInsetBounds()
+	size := label.layout.Size()
+	pt.X -= bounds.X
+	pt.Y -= bounds.Y + (bounds.Height-size.Height)/2
+	return label.layout.LinkAt(pt)
+}
+
+// SetWrap sets how the text is wrapped when it doesn't fit within the label's width. When
+// wrapping is enabled, the label's preferred height is determined by the width hint it is
+// given during layout.
+func (label *Label) SetWrap(wrap text.Wrap) {
+	if label.layout.Wrap() != wrap {
+		label.layout.SetWrap(wrap)
+		label.SetNeedLayout(true)
+		label.Repaint()
+	}
+}
+
+// SetAlignment sets how the lines of text are aligned horizontally.
+func (label *Label) SetAlignment(alignment text.Alignment) {
+	if label.layout.Alignment() != alignment {
+		label.layout.SetAlignment(alignment)
+		label.Repaint()
+	}
+}
+
+// SetEllipsize sets where the text is ellipsized when it doesn't fit within the label's width.
+func (label *Label) SetEllipsize(ellipsize text.Ellipsize) {
+	if label.layout.Ellipsize() != ellipsize {
+		label.layout.SetEllipsize(ellipsize)
+		label.Repaint()
+	}
+}
+
+// SetMaxLines sets the maximum number of lines shown. A value of 0 or less means there is no
+// limit.
+func (label *Label) SetMaxLines(maxLines int) {
+	if label.layout.MaxLines() != maxLines {
+		label.layout.SetMaxLines(maxLines)
+		label.SetNeedLayout(true)
+		label.Repaint()
+	}
 }
 
 // SetForeground sets the color used when drawing the text.
@@ -24,16 +24,7 @@ func (f *CellFactory) CellHeight() float64 {
 
 // CreateCell implements the widget.CellFactory interface.
 func (f *CellFactory) CreateCell(owner ui.Widget, element interface{}, index int, selected, focused bool) ui.Widget {
-	var text string
-	switch v := element.(type) {
-	case string:
-		text = v
-	case fmt.Stringer:
-		text = v.String()
-	default:
-		text = reflect.TypeOf(element).String()
-	}
-	label := NewWithFont(text, font.Views)
+	label := NewWithFont(f.CellText(element, index), font.Views)
 	if selected {
 		label.SetBackground(color.SelectedTextBackground)
 		label.SetForeground(color.SelectedText)
@@ -41,3 +32,15 @@ func (f *CellFactory) CreateCell(owner ui.Widget, element interface{}, index int
 	label.SetBorder(border.NewEmpty(geom.NewHorizontalInsets(4)))
 	return label
 }
+
+// CellText implements the widget.Searchable interface.
+func (f *CellFactory) CellText(element interface{}, index int) string {
+	switch v := element.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return reflect.TypeOf(element).String()
+	}
+}
@@ -0,0 +1,191 @@
+package widget
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/draw/compositing"
+)
+
+type layer struct {
+	surface   *draw.Surface
+	scale     float64
+	dirty     geom.Rect
+	opacity   float64
+	transform *xmath.Matrix2D
+}
+
+// Layered returns true if this block paints its subtree into a cached layer.
+func (b *Block) Layered() bool {
+	return b.layer != nil
+}
+
+// SetLayered controls whether this block paints its subtree into a cached layer. While the
+// layer is valid, painting the block simply copies the cached pixels, which makes this useful
+// for subtrees that are expensive to draw but change rarely. Calls to Repaint() or
+// RepaintBounds() on the block or any of its descendants invalidate the affected portion of
+// the layer.
+func (b *Block) SetLayered(layered bool) {
+	if layered != b.Layered() {
+		b.Repaint()
+		if layered {
+			b.layer = &layer{opacity: 1}
+		} else {
+			b.layer.release()
+			b.layer = nil
+		}
+		b.Repaint()
+	}
+}
+
+// ReleaseLayers releases the surfaces of any cached layers used by the widget or its descendants.
+// The layers remain enabled and are painted again the next time they are needed. This is done
+// automatically when a widget is removed from its parent or its window is closed.
+func ReleaseLayers(w ui.Widget) {
+	if lr, ok := w.(layerReleaser); ok {
+		lr.releaseLayer()
+	}
+	for _, child := range w.Children() {
+		ReleaseLayers(child)
+	}
+}
+
+type layerReleaser interface {
+	releaseLayer()
+}
+
+func (b *Block) releaseLayer() {
+	if b.layer != nil {
+		b.layer.release()
+	}
+}
+
+// LayerOpacity returns the opacity used when compositing the layer, from 0 to 1.
+func (b *Block) LayerOpacity() float64 {
+	if b.layer == nil {
+		return 1
+	}
+	return b.layer.opacity
+}
+
+// SetLayerOpacity sets the opacity used when compositing the layer, from 0 to 1. The block
+// becomes layered if it wasn't already. Changing the opacity does not invalidate the layer.
+func (b *Block) SetLayerOpacity(opacity float64) {
+	b.SetLayered(true)
+	opacity = math.Max(math.Min(opacity, 1), 0)
+	if b.layer.opacity != opacity {
+		b.layer.opacity = opacity
+		b.repaintLayer()
+	}
+}
+
+// LayerTransform returns the transform applied when compositing the layer, or nil if there is
+// none.
+func (b *Block) LayerTransform() *xmath.Matrix2D {
+	if b.layer == nil || b.layer.transform == nil {
+		return nil
+	}
+	transform := *b.layer.transform
+	return &transform
+}
+
+// SetLayerTransform sets the transform applied when compositing the layer, relative to the
+// block's top-left corner. The result is clipped to the block's bounds and does not affect
+// hit testing. The block becomes layered if it wasn't already. Pass nil to remove the
+// transform.
+func (b *Block) SetLayerTransform(transform *xmath.Matrix2D) {
+	b.SetLayered(true)
+	b.repaintLayer()
+	if transform != nil {
+		m := *transform
+		transform = &m
+	}
+	b.layer.transform = transform
+	b.repaintLayer()
+}
+
+// repaintLayer requests a repaint of the area covered by the layer without invalidating its
+// content.
+func (b *Block) repaintLayer() {
+	dirty := b.layer.dirty
+	b.Repaint()
+	b.layer.dirty = dirty
+}
+
+func (b *Block) paintLayer(gc *draw.Graphics, dirty geom.Rect) {
+	bounds := b.LocalBounds()
+	dirty.Intersect(bounds)
+	if dirty.IsEmpty() {
+		return
+	}
+	l := b.layer
+	scale := gc.DeviceScale()
+	if l.transform != nil {
+		scale *= math.Hypot(l.transform.XX, l.transform.YX)
+	}
+	size := geom.Size{Width: math.Ceil(bounds.Width * scale), Height: math.Ceil(bounds.Height * scale)}
+	if size.Width < 1 || size.Height < 1 {
+		return
+	}
+	if l.surface == nil || l.surface.Size() != size || l.scale != scale {
+		l.release()
+		l.surface = gc.CreateSimilarSurface(draw.ColorAndAlphaContent, size)
+		l.scale = scale
+		l.dirty = bounds
+	}
+	if !l.dirty.IsEmpty() {
+		area := l.dirty
+		l.dirty = geom.Rect{}
+		lgc := draw.NewGraphics(l.surface.NewCairoContext())
+		lgc.Scale(scale, scale)
+		lgc.Save()
+		lgc.Rect(area)
+		lgc.Clip()
+		lgc.SetCompositingOperator(compositing.Clear)
+		lgc.FillClip()
+		lgc.Restore()
+		b.paintContent(lgc, area)
+		lgc.Dispose()
+	}
+	gc.Save()
+	gc.Rect(dirty)
+	gc.Clip()
+	if l.transform != nil {
+		gc.Transform(l.transform)
+	}
+	gc.Scale(1/scale, 1/scale)
+	gc.Rect(geom.Rect{Size: size})
+	gc.Clip()
+	gc.SetSurface(l.surface, 0, 0)
+	gc.FillClipWithAlpha(l.opacity)
+	gc.Restore()
+}
+
+// invalidate marks 'bounds' as needing to be repainted within the layer and returns the area
+// the layer covers in the block's untransformed coordinates.
+func (l *layer) invalidate(bounds geom.Rect) geom.Rect {
+	l.dirty.Union(bounds)
+	if l.transform == nil {
+		return bounds
+	}
+	minPt := geom.Point{X: math.MaxFloat64, Y: math.MaxFloat64}
+	maxPt := geom.Point{X: -math.MaxFloat64, Y: -math.MaxFloat64}
+	for _, pt := range []geom.Point{bounds.Point, {X: bounds.X + bounds.Width, Y: bounds.Y}, {X: bounds.X, Y: bounds.Y + bounds.Height}, {X: bounds.X + bounds.Width, Y: bounds.Y + bounds.Height}} {
+		pt = l.transform.TransformPoint(pt)
+		minPt.X = math.Min(minPt.X, pt.X)
+		minPt.Y = math.Min(minPt.Y, pt.Y)
+		maxPt.X = math.Max(maxPt.X, pt.X)
+		maxPt.Y = math.Max(maxPt.Y, pt.Y)
+	}
+	return geom.Rect{Point: minPt, Size: geom.Size{Width: maxPt.X - minPt.X, Height: maxPt.Y - minPt.Y}}
+}
+
+func (l *layer) release() {
+	if l.surface != nil {
+		l.surface.Destroy()
+		l.surface = nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/find"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
 	"github.com/richardwilkes/ui/widget"
@@ -23,6 +24,7 @@ type List struct {
 	Selection      xmath.BitSet
 	savedSelection *xmath.BitSet
 	anchor         int
+	findMatcher    *find.Matcher
 	pressed        bool
 }
 
@@ -132,6 +134,34 @@ func (list *List) rowAt(y float64) (index int, top float64) {
 	return
 }
 
+func (list *List) rowBounds(index int) geom.Rect {
+	bounds := list.LocalInsetBounds()
+	cellHeight := math.Ceil(list.factory.CellHeight())
+	if cellHeight < 1 {
+		for i := 0; i <= index; i++ {
+			cell := list.factory.CreateCell(list, list.rows[i], i, false, false)
+			_, pref, _ := ui.Sizes(cell, layout.NoHintSize)
+			pref.GrowToInteger()
+			if i == index {
+				bounds.Height = pref.Height
+			} else {
+				bounds.Y += pref.Height
+			}
+		}
+	} else {
+		bounds.Y += float64(index) * cellHeight
+		bounds.Height = cellHeight
+	}
+	return bounds
+}
+
+// ScrollRowIntoView attempts to scroll the row at the specified index into view.
+func (list *List) ScrollRowIntoView(index int) {
+	if index >= 0 && index < len(list.rows) {
+		list.ScrollRectIntoView(list.rowBounds(index))
+	}
+}
+
 func (list *List) mouseDown(evt event.Event) {
 	list.Window().SetFocus(list)
 	if e, ok := evt.(*event.MouseDown); ok {
@@ -228,6 +258,9 @@ func (list *List) paint(evt event.Event) {
 				if selected {
 					gc.SetColor(color.SelectedTextBackground)
 					gc.FillRect(geom.Rect{Point: geom.Point{X: fullBounds.X, Y: cellBounds.Y}, Size: geom.Size{Width: fullBounds.Width, Height: cellBounds.Height}})
+				} else if list.findMatcher != nil && list.findMatcher.Contains(list.rowText(index)) {
+					gc.SetColor(color.FindHighlight)
+					gc.FillRect(geom.Rect{Point: geom.Point{X: fullBounds.X, Y: cellBounds.Y}, Size: geom.Size{Width: fullBounds.Width, Height: cellBounds.Height}})
 				}
 				gc.Save()
 				tl := cellBounds.Point
@@ -265,6 +298,7 @@ func (list *List) keyDown(evt event.Event) {
 					}
 				}
 				list.Select(e.Modifiers().ShiftDown(), first)
+				list.ScrollRowIntoView(first)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
 				evt.Finish()
@@ -273,14 +307,17 @@ func (list *List) keyDown(evt event.Event) {
 					last = len(list.rows) - 1
 				}
 				list.Select(e.Modifiers().ShiftDown(), last)
+				list.ScrollRowIntoView(last)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyHome, keys.VirtualKeyNumPadHome:
 				evt.Finish()
 				list.Select(e.Modifiers().ShiftDown(), 0)
+				list.ScrollRowIntoView(0)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyEnd, keys.VirtualKeyNumPadEnd:
 				evt.Finish()
 				list.Select(e.Modifiers().ShiftDown(), len(list.rows)-1)
+				list.ScrollRowIntoView(len(list.rows) - 1)
 				event.Dispatch(event.NewSelection(list))
 			}
 		}
@@ -332,3 +369,53 @@ func (list *List) Select(append bool, index ...int) {
 	}
 	list.Repaint()
 }
+
+// FindNext implements find.Findable. Rows are matched against the text provided by the cell
+// factory, if it implements widget.Searchable, or against the row's value formatted with fmt.Sprint
+// otherwise.
+func (list *List) FindNext(matcher *find.Matcher) bool {
+	count := len(list.rows)
+	start := list.Selection.LastSet() + 1
+	for i := 0; i < count; i++ {
+		if index := (start + i) % count; matcher.Contains(list.rowText(index)) {
+			list.selectFound(index)
+			return true
+		}
+	}
+	return false
+}
+
+// FindPrevious implements find.Findable.
+func (list *List) FindPrevious(matcher *find.Matcher) bool {
+	count := len(list.rows)
+	start := list.Selection.FirstSet() - 1
+	if start < 0 {
+		start = count - 1
+	}
+	for i := 0; i < count; i++ {
+		if index := (start - i + count) % count; matcher.Contains(list.rowText(index)) {
+			list.selectFound(index)
+			return true
+		}
+	}
+	return false
+}
+
+// SetFindHighlight implements find.Findable.
+func (list *List) SetFindHighlight(matcher *find.Matcher) {
+	list.findMatcher = matcher
+	list.Repaint()
+}
+
+func (list *List) selectFound(index int) {
+	list.Select(false, index)
+	list.ScrollRowIntoView(index)
+	event.Dispatch(event.NewSelection(list))
+}
+
+func (list *List) rowText(index int) string {
+	if searchable, ok := list.factory.(widget.Searchable); ok {
+		return searchable.CellText(list.rows[index], index)
+	}
+	return fmt.Sprint(list.rows[index])
+}
@@ -0,0 +1,188 @@
+package printpreview
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/layout"
+	"github.com/richardwilkes/ui/print"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// Preview shows the pages of a print job stacked vertically, at an adjustable zoom. It is
+// typically placed within a ScrollArea.
+type Preview struct {
+	widget.Block
+	Theme *Theme // The theme the Preview will use to draw itself.
+	job   *print.Job
+	zoom  float64
+}
+
+// New creates a new Preview for the print job.
+func New(job *print.Job) *Preview {
+	preview := &Preview{Theme: StdTheme, job: job, zoom: 1}
+	preview.InitTypeAndID(preview)
+	preview.Describer = func() string { return fmt.Sprintf("Preview #%d", preview.ID()) }
+	preview.SetBackground(preview.Theme.Background)
+	preview.SetFocusable(true)
+	preview.SetGrabFocusWhenClickedOn(true)
+	preview.SetSizer(preview)
+	handlers := preview.EventHandlers()
+	handlers.Add(event.PaintType, preview.paint)
+	handlers.Add(event.KeyDownType, preview.keyDown)
+	handlers.Add(event.MouseWheelType, preview.mouseWheel)
+	return preview
+}
+
+// Job returns the print job being previewed.
+func (preview *Preview) Job() *print.Job {
+	return preview.job
+}
+
+// SetJob sets the print job to preview.
+func (preview *Preview) SetJob(job *print.Job) {
+	if preview.job != job {
+		preview.job = job
+		preview.Invalidate()
+	}
+}
+
+// Zoom returns the current zoom factor. 1 shows pages at 72 pixels per inch.
+func (preview *Preview) Zoom() float64 {
+	return preview.zoom
+}
+
+// SetZoom sets the zoom factor, constrained to the theme's limits.
+func (preview *Preview) SetZoom(zoom float64) {
+	zoom = math.Max(math.Min(zoom, preview.Theme.MaxZoom), preview.Theme.MinZoom)
+	if preview.zoom != zoom {
+		preview.zoom = zoom
+		preview.Invalidate()
+	}
+}
+
+// ZoomIn increases the zoom factor by the theme's ZoomStep.
+func (preview *Preview) ZoomIn() {
+	preview.SetZoom(preview.zoom * preview.Theme.ZoomStep)
+}
+
+// ZoomOut decreases the zoom factor by the theme's ZoomStep.
+func (preview *Preview) ZoomOut() {
+	preview.SetZoom(preview.zoom / preview.Theme.ZoomStep)
+}
+
+// Invalidate marks the preview, and its ancestors, as needing layout and repaints it. Call this
+// if the job's page setup or content changes.
+func (preview *Preview) Invalidate() {
+	var target ui.Widget = preview
+	for target != nil {
+		target.SetNeedLayout(true)
+		target = target.Parent()
+	}
+	preview.Repaint()
+}
+
+// Sizes implements Sizer
+func (preview *Preview) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	gap := preview.Theme.Gap
+	pageSize := preview.scaledPageSize()
+	count := float64(preview.pageCount())
+	pref.Width = math.Ceil(pageSize.Width + 2*gap)
+	pref.Height = math.Ceil(count*(pageSize.Height+gap) + gap)
+	if border := preview.Border(); border != nil {
+		pref.AddInsets(border.Insets())
+	}
+	return pref, pref, layout.DefaultMaxSize(pref)
+}
+
+// PageBounds returns the area the page at 'pageIndex' occupies, in local coordinates.
+func (preview *Preview) PageBounds(pageIndex int) geom.Rect {
+	bounds := preview.LocalInsetBounds()
+	gap := preview.Theme.Gap
+	pageSize := preview.scaledPageSize()
+	x := bounds.X + math.Max((bounds.Width-pageSize.Width)/2, gap)
+	y := bounds.Y + gap + float64(pageIndex)*(pageSize.Height+gap)
+	return geom.Rect{Point: geom.Point{X: math.Floor(x), Y: math.Floor(y)}, Size: pageSize}
+}
+
+func (preview *Preview) pageCount() int {
+	if preview.job == nil {
+		return 0
+	}
+	return preview.job.PageCount()
+}
+
+func (preview *Preview) scaledPageSize() geom.Size {
+	if preview.job == nil {
+		return geom.Size{}
+	}
+	size := preview.job.Setup.PageSize()
+	return geom.Size{Width: math.Ceil(size.Width * preview.zoom), Height: math.Ceil(size.Height * preview.zoom)}
+}
+
+func (preview *Preview) paint(evt event.Event) {
+	e := evt.(*event.Paint)
+	gc := e.GC()
+	dirty := e.DirtyRect()
+	count := preview.pageCount()
+	for i := 0; i < count; i++ {
+		bounds := preview.PageBounds(i)
+		if bounds.Y > dirty.Y+dirty.Height {
+			break
+		}
+		shadow := bounds
+		shadow.X += preview.Theme.ShadowOffset
+		shadow.Y += preview.Theme.ShadowOffset
+		area := bounds
+		area.Union(shadow)
+		area.Intersect(dirty)
+		if area.IsEmpty() {
+			continue
+		}
+		gc.SetColor(preview.Theme.ShadowColor)
+		gc.FillRect(shadow)
+		gc.SetColor(preview.Theme.PageColor)
+		gc.FillRect(bounds)
+		gc.Save()
+		gc.Rect(bounds)
+		gc.Clip()
+		gc.Translate(bounds.X, bounds.Y)
+		gc.Scale(preview.zoom, preview.zoom)
+		preview.job.DrawPage(gc, i)
+		gc.Restore()
+		gc.SetColor(preview.Theme.EdgeColor)
+		gc.StrokeRect(geom.Rect{Point: geom.Point{X: bounds.X + 0.5, Y: bounds.Y + 0.5}, Size: geom.Size{Width: bounds.Width - 1, Height: bounds.Height - 1}})
+	}
+}
+
+func (preview *Preview) keyDown(evt event.Event) {
+	e := evt.(*event.KeyDown)
+	if !e.Modifiers().PlatformMenuModifierDown() {
+		return
+	}
+	switch e.Rune() {
+	case '+', '=':
+		preview.ZoomIn()
+		evt.Finish()
+	case '-':
+		preview.ZoomOut()
+		evt.Finish()
+	case '0':
+		preview.SetZoom(1)
+		evt.Finish()
+	}
+}
+
+func (preview *Preview) mouseWheel(evt event.Event) {
+	e := evt.(*event.MouseWheel)
+	if !e.Modifiers().PlatformMenuModifierDown() {
+		return
+	}
+	if delta := e.Delta().Y; delta != 0 {
+		preview.SetZoom(preview.zoom * math.Pow(preview.Theme.ZoomStep, -delta))
+		evt.Finish()
+	}
+}
@@ -0,0 +1,43 @@
+package printpreview
+
+import (
+	"github.com/richardwilkes/ui/color"
+)
+
+var (
+	// StdTheme is the theme all new Previews get by default.
+	StdTheme = NewTheme()
+)
+
+// Theme contains the theme elements for Previews.
+type Theme struct {
+	Background   color.Color // The color behind the pages.
+	PageColor    color.Color // The color of the paper.
+	EdgeColor    color.Color // The color of the line around each page.
+	ShadowColor  color.Color // The color of the shadow cast by each page.
+	ShadowOffset float64     // The distance the shadow is offset from each page.
+	Gap          float64     // The space around and between pages.
+	MinZoom      float64     // The smallest zoom factor allowed.
+	MaxZoom      float64     // The largest zoom factor allowed.
+	ZoomStep     float64     // The factor the zoom is multiplied or divided by when zooming in or out.
+}
+
+// NewTheme creates a new Preview theme.
+func NewTheme() *Theme {
+	theme := &Theme{}
+	theme.Init()
+	return theme
+}
+
+// Init initializes the theme with its default values.
+func (theme *Theme) Init() {
+	theme.Background = color.Background.AdjustBrightness(-0.1)
+	theme.PageColor = color.White
+	theme.EdgeColor = color.Background.AdjustBrightness(-0.4)
+	theme.ShadowColor = color.Black.SetAlphaIntensity(0.25)
+	theme.ShadowOffset = 3
+	theme.Gap = 16
+	theme.MinZoom = 0.1
+	theme.MaxZoom = 8
+	theme.ZoomStep = 1.25
+}
@@ -0,0 +1,67 @@
+package widget
+
+import (
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/layout"
+)
+
+// RenderFormat holds the type of output produced by RenderTo.
+type RenderFormat int
+
+// Possible RenderFormat values.
+const (
+	PNG RenderFormat = iota
+	SVG
+	PDF
+)
+
+// RenderTo lays out and paints the widget, along with its children, onto an
+// offscreen surface and writes the result to 'out' in the specified format.
+// If the widget has no size yet, it is first sized to its preferred size.
+// 'scale' is applied to the widget's coordinates; values less than or equal
+// to zero are treated as 1.
+func RenderTo(w ui.Widget, format RenderFormat, out io.Writer, scale float64) error {
+	if scale <= 0 {
+		scale = 1
+	}
+	if bounds := w.Bounds(); bounds.IsEmpty() {
+		_, pref, _ := ui.Sizes(w, layout.NoHintSize)
+		w.SetSize(pref)
+	}
+	w.ValidateLayout()
+	bounds := w.LocalBounds()
+	if bounds.IsEmpty() {
+		return errs.New("widget has no area to render")
+	}
+	size := geom.Size{Width: math.Ceil(bounds.Width * scale), Height: math.Ceil(bounds.Height * scale)}
+	var surface *draw.Surface
+	switch format {
+	case PNG:
+		surface = draw.NewImageSurface(size)
+	case SVG:
+		surface = draw.NewSVGSurface(out, size)
+	case PDF:
+		surface = draw.NewPDFSurface(out, size)
+	default:
+		return errs.Newf("unknown render format: %d", format)
+	}
+	defer surface.Destroy()
+	gc := draw.NewGraphics(surface.NewCairoContext())
+	gc.Scale(scale, scale)
+	w.Paint(gc, bounds)
+	gc.Dispose()
+	if format == PNG {
+		if err := png.Encode(out, surface.ImageData()); err != nil {
+			return errs.Wrap(err)
+		}
+		return nil
+	}
+	return surface.Finish()
+}
@@ -0,0 +1,35 @@
+package widget
+
+import (
+	"math"
+
+	"github.com/richardwilkes/ui"
+)
+
+// scrollContainer is implemented by widgets, such as scrollarea.ScrollArea, that show a portion of
+// their content through a scrollable viewport.
+type scrollContainer interface {
+	Content() ui.Widget
+	ScrolledPosition(horizontal bool) float64
+	SetScrolledPosition(horizontal bool, position float64)
+	VisibleSize(horizontal bool) float64
+	ContentSize(horizontal bool) float64
+}
+
+// scrollToReveal adjusts the scrolled position of 'sc' along one axis as little as possible to
+// reveal the span described by 'start' and 'length', which are in content coordinates. If the span
+// is larger than the visible area, its start is revealed.
+func scrollToReveal(sc scrollContainer, horizontal bool, start, length float64) {
+	pos := sc.ScrolledPosition(horizontal)
+	visible := sc.VisibleSize(horizontal)
+	target := pos
+	if start < pos || length > visible {
+		target = start
+	} else if start+length > pos+visible {
+		target = start + length - visible
+	}
+	target = math.Max(math.Min(target, sc.ContentSize(horizontal)-visible), 0)
+	if target != pos {
+		sc.SetScrolledPosition(horizontal, target)
+	}
+}
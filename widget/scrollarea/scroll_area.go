@@ -3,8 +3,11 @@ package scrollarea
 import (
 	"fmt"
 	"math"
+	"time"
 
+	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/animation"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/keys"
@@ -27,12 +30,17 @@ type Behavior int
 // viewport.
 type ScrollArea struct {
 	widget.Block
-	Theme    *Theme // The theme the ScrollArea will use to draw itself.
-	hBar     *scrollbar.ScrollBar
-	vBar     *scrollbar.ScrollBar
-	view     *widget.Block
-	content  ui.Widget
-	behavior Behavior
+	Theme            *Theme // The theme the ScrollArea will use to draw itself.
+	hBar             *scrollbar.ScrollBar
+	vBar             *scrollbar.ScrollBar
+	view             *widget.Block
+	content          ui.Widget
+	behavior         Behavior
+	scrollAnimation  *animation.Handle
+	scrollTarget     geom.Point
+	velocity         geom.Point
+	lastPreciseWheel time.Time
+	applyingScroll   bool
 }
 
 // New creates a new ScrollArea with the specified block as its content. The content may be nil.
@@ -127,8 +135,12 @@ func (sa *ScrollArea) ScrolledPosition(horizontal bool) float64 {
 	return -loc.Y
 }
 
-// SetScrolledPosition implements Scrollable.
+// SetScrolledPosition implements Scrollable. Any animated scroll that is in progress is stopped, so
+// that it doesn't move the content away from the new position.
 func (sa *ScrollArea) SetScrolledPosition(horizontal bool, position float64) {
+	if !sa.applyingScroll {
+		sa.stopScrollAnimation()
+	}
 	if sa.content != nil {
 		loc := sa.content.Location()
 		if horizontal {
@@ -179,17 +191,6 @@ func (sa *ScrollArea) viewResized(evt event.Event) {
 	}
 }
 
-func (sa *ScrollArea) mouseWheel(evt event.Event) {
-	delta := evt.(*event.MouseWheel).Delta()
-	if delta.Y != 0 {
-		sa.vBar.SetScrolledPosition(sa.ScrolledPosition(false) - delta.Y*sa.LineScrollAmount(false, delta.Y > 0))
-	}
-	if delta.X != 0 {
-		sa.hBar.SetScrolledPosition(sa.ScrolledPosition(true) - delta.X*sa.LineScrollAmount(true, delta.X > 0))
-	}
-	evt.Finish()
-}
-
 func (sa *ScrollArea) focusGained(evt event.Event) {
 	if sa.content == nil || sa.content.ID() == evt.Target().ID() {
 		sa.view.SetBorder(sa.Theme.FocusBorder)
@@ -0,0 +1,127 @@
+package scrollarea
+
+import (
+	"math"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/animation"
+	"github.com/richardwilkes/ui/event"
+)
+
+// The initial rate of change of animation.ExpoOut, used to convert a velocity into the distance a
+// momentum animation needs to cover.
+const expoOutInitialSlope = 10 * math.Ln2
+
+// ScrollTo scrolls the content so that 'position' is at the top-left corner of the view. The
+// position is clamped to the valid range. If 'animated' is true and the theme's
+// SmoothScrollDuration is greater than zero, the change is animated.
+func (sa *ScrollArea) ScrollTo(position geom.Point, animated bool) {
+	sa.stopScrollAnimation()
+	position = sa.clampScrollPosition(position)
+	if animated && sa.Theme.SmoothScrollDuration > 0 && sa.Window() != nil {
+		sa.animateScroll(position, sa.Theme.SmoothScrollDuration, animation.EaseOut)
+	} else {
+		sa.applyScrollPosition(position)
+	}
+}
+
+// ScrollTarget returns the position the content is moving towards. When no animated scroll is in
+// progress, this is the current scrolled position.
+func (sa *ScrollArea) ScrollTarget() geom.Point {
+	if sa.scrollAnimation != nil && sa.scrollAnimation.Running() {
+		return sa.scrollTarget
+	}
+	return sa.currentScrollPosition()
+}
+
+func (sa *ScrollArea) currentScrollPosition() geom.Point {
+	return geom.Point{X: sa.ScrolledPosition(true), Y: sa.ScrolledPosition(false)}
+}
+
+func (sa *ScrollArea) clampScrollPosition(position geom.Point) geom.Point {
+	position.X = math.Max(math.Min(position.X, sa.ContentSize(true)-sa.VisibleSize(true)), 0)
+	position.Y = math.Max(math.Min(position.Y, sa.ContentSize(false)-sa.VisibleSize(false)), 0)
+	return position
+}
+
+// applyScrollPosition moves the content to 'position' without stopping any animated scroll, as it
+// is also what animated scrolls use to move the content.
+func (sa *ScrollArea) applyScrollPosition(position geom.Point) {
+	sa.applyingScroll = true
+	sa.hBar.SetScrolledPosition(position.X)
+	sa.vBar.SetScrolledPosition(position.Y)
+	sa.applyingScroll = false
+}
+
+func (sa *ScrollArea) animateScroll(to geom.Point, duration time.Duration, easing animation.Easing) {
+	sa.scrollTarget = to
+	tween := animation.NewPoint(sa.currentScrollPosition(), to, duration, sa.applyScrollPosition)
+	tween.Easing = easing
+	sa.scrollAnimation = animation.Start(sa.Window(), tween)
+}
+
+func (sa *ScrollArea) stopScrollAnimation() {
+	if sa.scrollAnimation != nil {
+		sa.scrollAnimation.Stop()
+		sa.scrollAnimation = nil
+	}
+}
+
+func (sa *ScrollArea) wheelAmount(delta geom.Point) geom.Point {
+	var amount geom.Point
+	if delta.X != 0 {
+		amount.X = delta.X * sa.LineScrollAmount(true, delta.X > 0)
+	}
+	if delta.Y != 0 {
+		amount.Y = delta.Y * sa.LineScrollAmount(false, delta.Y > 0)
+	}
+	return amount
+}
+
+func (sa *ScrollArea) mouseWheel(evt event.Event) {
+	e := evt.(*event.MouseWheel)
+	switch e.Phase() {
+	case event.WheelPhaseNone:
+		amount := sa.wheelAmount(e.Delta())
+		target := sa.ScrollTarget()
+		target.Subtract(amount)
+		sa.ScrollTo(target, true)
+	case event.WheelPhaseBegan, event.WheelPhaseChanged:
+		sa.stopScrollAnimation()
+		amount := sa.wheelAmount(e.Delta())
+		position := sa.currentScrollPosition()
+		position.Subtract(amount)
+		sa.applyScrollPosition(position)
+		now := time.Now()
+		if e.Phase() == event.WheelPhaseBegan {
+			sa.velocity = geom.Point{}
+		} else if elapsed := now.Sub(sa.lastPreciseWheel).Seconds(); elapsed > 0 {
+			// Smooth the velocity somewhat, as the intervals between events can be quite uneven
+			sa.velocity.X = (sa.velocity.X - amount.X/elapsed) / 2
+			sa.velocity.Y = (sa.velocity.Y - amount.Y/elapsed) / 2
+		}
+		sa.lastPreciseWheel = now
+	case event.WheelPhaseEnded:
+		sa.startMomentum()
+	}
+	evt.Finish()
+}
+
+func (sa *ScrollArea) startMomentum() {
+	velocity := sa.velocity
+	sa.velocity = geom.Point{}
+	if !sa.Theme.Momentum || sa.Theme.MomentumDuration <= 0 || sa.Window() == nil {
+		return
+	}
+	if math.Hypot(velocity.X, velocity.Y) < sa.Theme.MinimumMomentumVelocity {
+		return
+	}
+	scale := sa.Theme.MomentumDuration.Seconds() / expoOutInitialSlope
+	target := sa.currentScrollPosition()
+	target.Add(geom.Point{X: velocity.X * scale, Y: velocity.Y * scale})
+	target = sa.clampScrollPosition(target)
+	if target != sa.currentScrollPosition() {
+		sa.animateScroll(target, sa.Theme.MomentumDuration, animation.ExpoOut)
+	}
+}
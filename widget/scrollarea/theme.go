@@ -1,6 +1,8 @@
 package scrollarea
 
 import (
+	"time"
+
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
@@ -13,8 +15,12 @@ var (
 
 // Theme contains the theme elements for ScrollAreas.
 type Theme struct {
-	Border      border.Border // The border to use when not focused.
-	FocusBorder border.Border // The border to use when focused.
+	Border                  border.Border // The border to use when not focused.
+	FocusBorder             border.Border // The border to use when focused.
+	SmoothScrollDuration    time.Duration // The duration of animated scrolls. Zero disables animation.
+	Momentum                bool          // Whether precise scroll gestures continue with momentum after they end.
+	MomentumDuration        time.Duration // The duration of the momentum animation.
+	MinimumMomentumVelocity float64       // The velocity, in pixels per second, a gesture must exceed to produce momentum.
 }
 
 // NewTheme creates a new ScrollArea theme.
@@ -30,4 +36,8 @@ func (theme *Theme) Init() {
 	lineBorder := border.NewLine(color.KeyboardFocus, geom.NewUniformInsets(2))
 	lineBorder.NoInset = true
 	theme.FocusBorder = lineBorder
+	theme.SmoothScrollDuration = 150 * time.Millisecond
+	theme.Momentum = true
+	theme.MomentumDuration = time.Second
+	theme.MinimumMomentumVelocity = 100
 }
@@ -7,16 +7,23 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/app"
 	"github.com/richardwilkes/ui/clipboard"
 	"github.com/richardwilkes/ui/clipboard/datatypes"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/event/button"
+	"github.com/richardwilkes/ui/find"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/spell"
+	"github.com/richardwilkes/ui/text"
 	"github.com/richardwilkes/ui/widget"
 	"github.com/richardwilkes/ui/window"
 )
@@ -25,22 +32,34 @@ import (
 type TextField struct {
 	widget.Block
 	runes           []rune
+	layout          *text.Layout
+	findMatcher     *find.Matcher
+	spelling        *spell.Background
+	misspelled      []spell.Range
 	watermark       string
 	Theme           *Theme // The theme the text field will use to draw itself.
 	selectionStart  int
 	selectionEnd    int
 	selectionAnchor int
+	revealIndex     int
 	forceShowUntil  time.Time
+	revealUntil     time.Time
 	scrollOffset    float64
 	showCursor      bool
 	pending         bool
 	extendByWord    bool
 	invalid         bool
+	secure          bool
+	revealLastTyped bool
+	capsLock        bool
 }
 
 // New creates a new, empty, text field.
 func New() *TextField {
-	field := &TextField{Theme: StdTheme}
+	field := &TextField{Theme: StdTheme, revealIndex: -1}
+	field.layout = text.NewLayout("", field.Theme.Font)
+	field.spelling = spell.NewBackground(app.Invoke, field.spellingChecked)
+	field.spelling.SetChecker(spell.Default())
 	field.InitTypeAndID(field)
 	field.Describer = func() string { return fmt.Sprintf("TextField #%d", field.ID()) }
 	field.SetBackground(color.TextBackground)
@@ -55,7 +74,9 @@ func New() *TextField {
 	handlers.Add(event.MouseDownType, field.mouseDown)
 	handlers.Add(event.MouseDraggedType, field.mouseDragged)
 	handlers.Add(event.KeyDownType, field.keyDown)
+	handlers.Add(event.KeyUpType, field.keyUp)
 	handlers.Add(event.UpdateCursorType, field.setCursor)
+	handlers.Add(event.SystemFontsChangedType, field.systemFontsChanged)
 	return field
 }
 
@@ -73,7 +94,7 @@ func (field *TextField) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 	}
 	var text string
 	if len(field.runes) != 0 {
-		text = string(field.runes)
+		text = field.displayText()
 	} else {
 		text = "M"
 	}
@@ -88,7 +109,7 @@ func (field *TextField) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 
 func (field *TextField) paint(evt event.Event) {
 	if e, ok := evt.(*event.Paint); ok {
-		bounds := field.LocalInsetBounds()
+		bounds := field.textBounds()
 		gc := e.GC()
 		gc.Save()
 		defer gc.Restore()
@@ -99,43 +120,59 @@ func (field *TextField) paint(evt event.Event) {
 			gc.SetColor(field.Theme.DisabledBackgroundColor)
 			gc.FillRect(e.DirtyRect())
 		}
+		if field.showingCapsLockWarning() {
+			field.drawCapsLockWarning(gc)
+		}
 		gc.Rect(bounds)
 		gc.Clip()
 		textTop := bounds.Y + (bounds.Height-field.Theme.Font.Height())/2
-		if field.HasSelectionRange() {
-			left := bounds.X + field.scrollOffset
-			if field.selectionStart > 0 {
-				gc.SetColor(color.Text)
-				pre := string(field.runes[:field.selectionStart])
-				gc.DrawString(left, textTop, pre, field.Theme.Font)
-				left += field.Theme.Font.Measure(pre).Width
-			}
-			mid := string(field.runes[field.selectionStart:field.selectionEnd])
-			right := bounds.X + field.Theme.Font.Measure(string(field.runes[:field.selectionEnd])).Width + field.scrollOffset
-			selRect := geom.Rect{Point: geom.Point{X: left, Y: textTop}, Size: geom.Size{Width: right - left, Height: field.Theme.Font.Height()}}
-			if field.Focused() {
-				gc.SetColor(color.SelectedTextBackground)
-				gc.FillRect(selRect)
-			} else {
-				gc.SetColor(color.SelectedTextBackground)
-				gc.SetStrokeWidth(2)
-				selRect.InsetUniform(0.5)
-				gc.StrokeRect(selRect)
-			}
-			gc.SetColor(color.SelectedText)
-			gc.DrawString(left, textTop, mid, field.Theme.Font)
-			if field.selectionStart < len(field.runes) {
-				gc.SetColor(color.Text)
-				gc.DrawString(right, textTop, string(field.runes[field.selectionEnd:]), field.Theme.Font)
-			}
-		} else if len(field.runes) == 0 {
+		left := bounds.X + field.scrollOffset
+		textLayout := field.textLayout()
+		if len(field.runes) == 0 {
 			if field.watermark != "" {
 				gc.SetColor(color.Gray)
 				gc.DrawString(bounds.X, textTop, field.watermark, field.Theme.Font)
 			}
 		} else {
+			if field.findMatcher != nil && !field.secure {
+				gc.SetColor(color.FindHighlight)
+				for _, match := range field.findMatcher.Matches(string(field.runes)) {
+					for _, r := range textLayout.SelectionRects(match.Start, match.End) {
+						r.X += left
+						r.Y = textTop
+						r.Height = field.Theme.Font.Height()
+						gc.FillRect(r)
+					}
+				}
+			}
 			gc.SetColor(color.Text)
-			gc.DrawString(bounds.X+field.scrollOffset, textTop, string(field.runes), field.Theme.Font)
+			textLayout.Draw(gc, left, textTop)
+			field.drawMisspellings(gc, textLayout, left, textTop)
+			if field.HasSelectionRange() {
+				rects := textLayout.SelectionRects(field.selectionStart, field.selectionEnd)
+				gc.SetColor(color.SelectedTextBackground)
+				for i := range rects {
+					rects[i].X += left
+					rects[i].Y = textTop
+					rects[i].Height = field.Theme.Font.Height()
+					if field.Focused() {
+						gc.FillRect(rects[i])
+					} else {
+						gc.SetStrokeWidth(2)
+						selRect := rects[i]
+						selRect.InsetUniform(0.5)
+						gc.StrokeRect(selRect)
+					}
+				}
+				gc.Save()
+				for _, r := range rects {
+					gc.Rect(r)
+				}
+				gc.Clip()
+				gc.SetColor(color.SelectedText)
+				textLayout.Draw(gc, left, textTop)
+				gc.Restore()
+			}
 		}
 		if !field.HasSelectionRange() && field.Focused() {
 			if field.showCursor {
@@ -145,7 +182,8 @@ func (field *TextField) paint(evt event.Event) {
 				} else {
 					cursorColor = color.White
 				}
-				x := bounds.X + field.Theme.Font.Measure(string(field.runes[:field.selectionEnd])).Width + field.scrollOffset
+				strong, _ := textLayout.CursorPosition(field.selectionEnd)
+				x := left + strong.X
 				gc.SetColor(cursorColor)
 				gc.StrokeLine(x, textTop, x, textTop+field.Theme.Font.Height()-1)
 			}
@@ -154,6 +192,84 @@ func (field *TextField) paint(evt event.Event) {
 	}
 }
 
+// showingCapsLockWarning returns true if caps lock is on while a secure field has the keyboard
+// focus, in which case a warning is shown at its trailing edge.
+func (field *TextField) showingCapsLockWarning() bool {
+	return field.secure && field.capsLock && field.Focused()
+}
+
+// textBounds returns the area the text is drawn and scrolled within: the inset bounds, less the
+// room taken by the caps lock warning while it is shown.
+func (field *TextField) textBounds() geom.Rect {
+	bounds := field.LocalInsetBounds()
+	if field.showingCapsLockWarning() {
+		width := math.Min(field.Theme.Font.Measure(keys.CapsLockName).Width+field.Theme.CapsLockWarningSpacing, bounds.Width)
+		if field.textLayout().RightToLeft() {
+			bounds.X += width
+		}
+		bounds.Width -= width
+	}
+	return bounds
+}
+
+// drawCapsLockWarning draws the caps lock symbol at the trailing edge of the field, to warn that
+// the text being entered, which can't be seen, may not be what was intended.
+func (field *TextField) drawCapsLockWarning(gc *draw.Graphics) {
+	bounds := field.LocalInsetBounds()
+	f := field.Theme.Font
+	size := f.Measure(keys.CapsLockName)
+	x := bounds.X + bounds.Width - size.Width
+	if field.textLayout().RightToLeft() {
+		x = bounds.X
+	}
+	gc.Save()
+	gc.Rect(bounds)
+	gc.Clip()
+	gc.SetColor(field.Theme.CapsLockWarningColor)
+	gc.DrawString(x, bounds.Y+(bounds.Height-size.Height)/2, keys.CapsLockName, f)
+	gc.Restore()
+}
+
+func (field *TextField) drawMisspellings(gc *draw.Graphics, textLayout *text.Layout, left, textTop float64) {
+	if len(field.misspelled) == 0 {
+		return
+	}
+	f := field.Theme.Font
+	y := textTop + f.Leading() + f.Ascent() + math.Min(2, f.Descent()/2)
+	gc.Save()
+	gc.SetColor(color.SpellingError)
+	gc.SetStrokeWidth(1)
+	for _, word := range field.misspelled {
+		// The results may be from before the most recent edit, so skip any that no longer fit, as
+		// well as the word being typed.
+		if word.End > len(field.runes) || (field.Focused() && !field.HasSelectionRange() && field.selectionEnd == word.End) {
+			continue
+		}
+		for _, r := range textLayout.SelectionRects(word.Start, word.End) {
+			gc.AddPath(squiggle(left+r.X, left+r.X+r.Width, y))
+			gc.StrokePath()
+		}
+	}
+	gc.Restore()
+}
+
+// squiggle returns a path for a zig-zag line from 'x1' to 'x2', centered vertically on 'y'.
+func squiggle(x1, x2, y float64) *draw.Path {
+	const step = 2
+	path := draw.NewPath()
+	path.MoveTo(x1, y+step/2)
+	up := true
+	for x := x1 + step; x < x2; x += step {
+		if up {
+			path.LineTo(x, y-step/2)
+		} else {
+			path.LineTo(x, y+step/2)
+		}
+		up = !up
+	}
+	return path
+}
+
 func (field *TextField) scheduleBlink() {
 	window := field.Window()
 	if window.Valid() && !field.pending && field.Focused() {
@@ -176,10 +292,14 @@ func (field *TextField) blink() {
 func (field *TextField) focusGained(evt event.Event) {
 	field.SetBorder(field.Theme.FocusBorder)
 	field.showCursor = true
+	// Caps lock may already be on, with no key event to report it until one is pressed.
+	field.updateCapsLock(window.CurrentModifiers())
+	field.autoScroll()
 	field.Repaint()
 }
 
 func (field *TextField) focusLost(evt event.Event) {
+	field.hideRevealed()
 	field.SetBorder(field.Theme.Border)
 	field.Repaint()
 }
@@ -215,7 +335,7 @@ func (field *TextField) mouseDown(evt event.Event) {
 				field.setSelection(start, end, field.selectionAnchor)
 			}
 		} else if e.Button() == button.Right {
-			fmt.Println("right click")
+			field.showContextMenu(e.Where())
 		}
 	}
 }
@@ -265,6 +385,7 @@ func (field *TextField) mouseDragged(evt event.Event) {
 func (field *TextField) keyDown(evt event.Event) {
 	window.HideCursorUntilMouseMoves()
 	if e, ok := evt.(*event.KeyDown); ok {
+		field.updateCapsLock(e.Modifiers())
 		code := e.Code()
 		switch code {
 		case keys.VirtualKeyBackspace:
@@ -274,26 +395,17 @@ func (field *TextField) keyDown(evt event.Event) {
 			if field.HasSelectionRange() {
 				field.Delete()
 			} else if field.selectionStart < len(field.runes) {
-				field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionStart+1:]...)
+				next := field.textLayout().NextCursorPosition(field.selectionStart)
+				field.replaceRunes(field.selectionStart, next, nil)
 				field.notifyOfModification()
 			}
 			evt.Finish()
 			field.Repaint()
 		case keys.VirtualKeyLeft, keys.VirtualKeyNumPadLeft:
-			extend := e.Modifiers().ShiftDown()
-			if e.Modifiers().CommandDown() {
-				field.handleHome(extend)
-			} else {
-				field.handleArrowLeft(extend, e.Modifiers().OptionDown())
-			}
+			field.handleArrow(-1, e.Modifiers())
 			evt.Finish()
 		case keys.VirtualKeyRight, keys.VirtualKeyNumPadRight:
-			extend := e.Modifiers().ShiftDown()
-			if e.Modifiers().CommandDown() {
-				field.handleEnd(extend)
-			} else {
-				field.handleArrowRight(extend, e.Modifiers().OptionDown())
-			}
+			field.handleArrow(1, e.Modifiers())
 			evt.Finish()
 		case keys.VirtualKeyEnd, keys.VirtualKeyNumPadEnd, keys.VirtualKeyPageDown, keys.VirtualKeyNumPadPageDown, keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
 			field.handleEnd(e.Modifiers().ShiftDown())
@@ -304,18 +416,34 @@ func (field *TextField) keyDown(evt event.Event) {
 		default:
 			r := e.Rune()
 			if !unicode.IsControl(r) {
-				if field.HasSelectionRange() {
-					field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
-				}
-				field.runes = append(field.runes[:field.selectionStart], append([]rune{r}, field.runes[field.selectionStart:]...)...)
+				field.replaceRunes(field.selectionStart, field.selectionEnd, []rune{r})
 				field.SetSelectionTo(field.selectionStart + 1)
 				field.notifyOfModification()
+				if field.secure && field.revealLastTyped {
+					field.reveal(field.selectionStart - 1)
+				}
 				evt.Finish()
 			}
 		}
 	}
 }
 
+func (field *TextField) keyUp(evt event.Event) {
+	if e, ok := evt.(*event.KeyUp); ok {
+		field.updateCapsLock(e.Modifiers())
+	}
+}
+
+func (field *TextField) updateCapsLock(modifiers keys.Modifiers) {
+	if capsLock := modifiers.CapsLockDown(); capsLock != field.capsLock {
+		field.capsLock = capsLock
+		if field.secure {
+			field.autoScroll()
+			field.Repaint()
+		}
+	}
+}
+
 func (field *TextField) handleHome(extend bool) {
 	if extend {
 		field.setSelection(0, field.selectionEnd, field.selectionEnd)
@@ -332,75 +460,47 @@ func (field *TextField) handleEnd(extend bool) {
 	}
 }
 
-func (field *TextField) handleArrowLeft(extend, byWord bool) {
-	if field.HasSelectionRange() {
-		if extend {
-			anchor := field.selectionAnchor
-			if field.selectionStart == anchor {
-				pos := field.selectionEnd - 1
-				if byWord {
-					start, _ := field.findWordAt(pos)
-					pos = xmath.MinInt(xmath.MaxInt(start, anchor), pos)
-				}
-				field.setSelection(anchor, pos, anchor)
-			} else {
-				pos := field.selectionStart - 1
-				if byWord {
-					start, _ := field.findWordAt(pos)
-					pos = xmath.MinInt(start, pos)
-				}
-				field.setSelection(pos, anchor, anchor)
-			}
+// handleArrow moves the caret one position to the left, when 'dir' is negative, or to the right,
+// when 'dir' is positive, in visual order.
+func (field *TextField) handleArrow(dir int, modifiers keys.Modifiers) {
+	extend := modifiers.ShiftDown()
+	forward := (dir > 0) != field.textLayout().RightToLeft()
+	if modifiers.CommandDown() {
+		if forward {
+			field.handleEnd(extend)
 		} else {
-			field.SetSelectionTo(field.selectionStart)
+			field.handleHome(extend)
 		}
-	} else {
-		pos := field.selectionStart - 1
-		if byWord {
-			start, _ := field.findWordAt(pos)
-			pos = xmath.MinInt(start, pos)
-		}
-		if extend {
-			field.setSelection(pos, field.selectionStart, field.selectionEnd)
+		return
+	}
+	if field.HasSelectionRange() && !extend {
+		if forward {
+			field.SetSelectionTo(field.selectionEnd)
 		} else {
-			field.SetSelectionTo(pos)
+			field.SetSelectionTo(field.selectionStart)
 		}
+		return
 	}
-}
-
-func (field *TextField) handleArrowRight(extend, byWord bool) {
-	if field.HasSelectionRange() {
-		if extend {
-			anchor := field.selectionAnchor
-			if field.selectionEnd == anchor {
-				pos := field.selectionStart + 1
-				if byWord {
-					_, end := field.findWordAt(pos)
-					pos = xmath.MaxInt(xmath.MinInt(end, anchor), pos)
-				}
-				field.setSelection(pos, anchor, anchor)
-			} else {
-				pos := field.selectionEnd + 1
-				if byWord {
-					_, end := field.findWordAt(pos)
-					pos = xmath.MaxInt(end, pos)
-				}
-				field.setSelection(anchor, pos, anchor)
-			}
+	anchor := field.selectionAnchor
+	pos := field.selectionEnd
+	if field.HasSelectionRange() && field.selectionEnd == anchor {
+		pos = field.selectionStart
+	}
+	if modifiers.OptionDown() {
+		if forward {
+			_, end := field.findWordAt(pos + 1)
+			pos = xmath.MaxInt(end, pos+1)
 		} else {
-			field.SetSelectionTo(field.selectionEnd)
+			start, _ := field.findWordAt(pos - 1)
+			pos = xmath.MinInt(start, pos-1)
 		}
 	} else {
-		pos := field.selectionEnd + 1
-		if byWord {
-			_, end := field.findWordAt(pos)
-			pos = xmath.MaxInt(end, pos)
-		}
-		if extend {
-			field.SetSelection(field.selectionStart, pos)
-		} else {
-			field.SetSelectionTo(pos)
-		}
+		pos = field.textLayout().MoveCursorVisually(pos, dir)
+	}
+	if extend {
+		field.setSelection(xmath.MinInt(pos, anchor), xmath.MaxInt(pos, anchor), anchor)
+	} else {
+		field.SetSelectionTo(pos)
 	}
 }
 
@@ -413,7 +513,7 @@ func (field *TextField) Text() string {
 func (field *TextField) SetText(text string) bool {
 	text = sanitize(text)
 	if string(field.runes) != text {
-		field.runes = ([]rune)(text)
+		field.replaceRunes(0, len(field.runes), ([]rune)(text))
 		field.SetSelectionToEnd()
 		field.notifyOfModification()
 		return true
@@ -423,6 +523,10 @@ func (field *TextField) SetText(text string) bool {
 
 func (field *TextField) notifyOfModification() {
 	field.Repaint()
+	field.hideRevealed()
+	if !field.secure {
+		field.spelling.Check(string(field.runes))
+	}
 	event.Dispatch(event.NewModified(field))
 	ve := event.NewValidate(field)
 	event.Dispatch(ve)
@@ -524,44 +628,34 @@ func (field *TextField) setSelection(start, end, anchor int) {
 }
 
 func (field *TextField) autoScroll() {
-	bounds := field.LocalInsetBounds()
+	bounds := field.textBounds()
 	if bounds.Width > 0 {
 		original := field.scrollOffset
-		if field.selectionStart == field.selectionAnchor {
-			right := field.FromSelectionIndex(field.selectionEnd).X
-			if right < bounds.X {
-				field.scrollOffset = 0
-				field.scrollOffset = bounds.X - field.FromSelectionIndex(field.selectionEnd).X
-			} else if right >= bounds.X+bounds.Width {
-				field.scrollOffset = 0
-				field.scrollOffset = bounds.X + bounds.Width - 1 - field.FromSelectionIndex(field.selectionEnd).X
-			}
-		} else {
-			left := field.FromSelectionIndex(field.selectionStart).X
-			if left < bounds.X {
-				field.scrollOffset = 0
-				field.scrollOffset = bounds.X - field.FromSelectionIndex(field.selectionStart).X
-			} else if left >= bounds.X+bounds.Width {
-				field.scrollOffset = 0
-				field.scrollOffset = bounds.X + bounds.Width - 1 - field.FromSelectionIndex(field.selectionStart).X
-			}
-		}
-		save := field.scrollOffset
-		field.scrollOffset = 0
-		min := bounds.X + bounds.Width - 1 - field.FromSelectionIndex(len(field.runes)).X
-		if min > 0 {
-			min = 0
+		textLayout := field.textLayout()
+		index := field.selectionEnd
+		if field.selectionStart != field.selectionAnchor {
+			index = field.selectionStart
 		}
-		max := bounds.X - field.FromSelectionIndex(0).X
-		if max < 0 {
-			max = 0
+		strong, _ := textLayout.CursorPosition(index)
+		offset := field.scrollOffset
+		if strong.X+offset < 0 {
+			offset = -strong.X
+		} else if strong.X+offset >= bounds.Width {
+			offset = bounds.Width - 1 - strong.X
 		}
-		if save < min {
-			save = min
-		} else if save > max {
-			save = max
+		width := textLayout.Size().Width
+		if width < bounds.Width {
+			if textLayout.RightToLeft() {
+				offset = bounds.Width - 1 - width
+			} else {
+				offset = 0
+			}
+		} else if min := bounds.Width - 1 - width; offset < min {
+			offset = min
+		} else if offset > 0 {
+			offset = 0
 		}
-		field.scrollOffset = save
+		field.scrollOffset = offset
 		if original != field.scrollOffset {
 			field.Repaint()
 		}
@@ -570,27 +664,54 @@ func (field *TextField) autoScroll() {
 
 // ToSelectionIndex returns the rune index for the specified x-coordinate.
 func (field *TextField) ToSelectionIndex(x float64) int {
-	bounds := field.LocalInsetBounds()
-	return field.Theme.Font.IndexForPosition(x-(bounds.X+field.scrollOffset), string(field.runes))
+	bounds := field.textBounds()
+	return field.textLayout().IndexForPosition(geom.Point{X: x - (bounds.X + field.scrollOffset)})
 }
 
 // FromSelectionIndex returns a location in local coordinates for the specified rune index.
 func (field *TextField) FromSelectionIndex(index int) geom.Point {
-	bounds := field.LocalInsetBounds()
-	x := bounds.X + field.scrollOffset
-	top := bounds.Y + bounds.Height/2
-	if index > 0 {
-		length := len(field.runes)
-		if index > length {
-			index = length
-		}
-		x += field.Theme.Font.PositionForIndex(index, string(field.runes))
+	bounds := field.textBounds()
+	if length := len(field.runes); index > length {
+		index = length
 	}
-	return geom.Point{X: x, Y: top}
+	strong, _ := field.textLayout().CursorPosition(index)
+	return geom.Point{X: bounds.X + field.scrollOffset + strong.X, Y: bounds.Y + bounds.Height/2}
+}
+
+// Direction returns the base direction of the text.
+func (field *TextField) Direction() text.Direction {
+	return field.layout.Direction()
+}
+
+// SetDirection sets the base direction of the text. With text.DirectionAuto, the default, the
+// direction is determined by the first character with a strong direction. Right-to-left text is
+// aligned to the right edge of the field when it fits.
+func (field *TextField) SetDirection(direction text.Direction) {
+	if field.layout.Direction() != direction {
+		field.layout.SetDirection(direction)
+		field.autoScroll()
+		field.Repaint()
+	}
+}
+
+// textLayout returns the layout used for the field's text, updated to reflect its current content
+// and font.
+func (field *TextField) textLayout() *text.Layout {
+	if field.layout.Font() != field.Theme.Font {
+		field.layout.SetFont(field.Theme.Font)
+	}
+	if str := field.displayText(); field.layout.Text() != str {
+		field.layout.SetText(str)
+	}
+	return field.layout
 }
 
 func (field *TextField) findWordAt(pos int) (start, end int) {
 	length := len(field.runes)
+	if field.secure {
+		// Word boundaries would reveal where the spaces are, so treat the text as a single word.
+		return 0, length
+	}
 	if pos < 0 {
 		pos = 0
 	} else if pos >= length {
@@ -609,14 +730,251 @@ func (field *TextField) findWordAt(pos int) (start, end int) {
 	return start, end
 }
 
-// CanCut returns true if the field has a selection that can be cut.
+// FindNext implements find.Findable.
+func (field *TextField) FindNext(matcher *find.Matcher) bool {
+	if field.secure {
+		return false
+	}
+	match, found := matcher.Next(string(field.runes), field.selectionEnd)
+	if found {
+		field.SetSelection(match.Start, match.End)
+	}
+	return found
+}
+
+// FindPrevious implements find.Findable.
+func (field *TextField) FindPrevious(matcher *find.Matcher) bool {
+	if field.secure {
+		return false
+	}
+	match, found := matcher.Previous(string(field.runes), field.selectionStart)
+	if found {
+		field.SetSelection(match.Start, match.End)
+	}
+	return found
+}
+
+// SetFindHighlight implements find.Findable.
+func (field *TextField) SetFindHighlight(matcher *find.Matcher) {
+	field.findMatcher = matcher
+	field.Repaint()
+}
+
+// Secure returns true if the field is in secure mode.
+func (field *TextField) Secure() bool {
+	return field.secure
+}
+
+// SetSecure sets whether the field is in secure mode, which is intended for the entry of passwords
+// and other secrets. In secure mode, each character is displayed as the theme's SecureRune, the
+// text can't be copied or cut, its spelling isn't checked, and a warning is shown while caps lock
+// is on. Whenever the text is modified, the memory that held the previous text is cleared.
+func (field *TextField) SetSecure(secure bool) {
+	if field.secure != secure {
+		field.secure = secure
+		field.hideRevealed()
+		if secure {
+			field.spelling.Cancel()
+			field.misspelled = nil
+		} else {
+			field.spelling.Check(string(field.runes))
+		}
+		field.autoScroll()
+		field.Repaint()
+	}
+}
+
+// RevealLastTyped returns true if the last character typed into a secure field is briefly shown.
+func (field *TextField) RevealLastTyped() bool {
+	return field.revealLastTyped
+}
+
+// SetRevealLastTyped sets whether the last character typed into a secure field is shown for the
+// theme's RevealDuration before being masked, as is common on touch devices.
+func (field *TextField) SetRevealLastTyped(reveal bool) {
+	field.revealLastTyped = reveal
+	if !reveal {
+		field.hideRevealed()
+	}
+}
+
+// Clear removes all of the text from the field, clearing the memory that held it.
+func (field *TextField) Clear() {
+	if len(field.runes) != 0 {
+		field.replaceRunes(0, len(field.runes), nil)
+		field.SetSelectionToStart()
+		field.notifyOfModification()
+	}
+}
+
+// replaceRunes replaces the runes from 'start' up to, but not including, 'end' with 'runes'. A
+// new buffer is always allocated, so that the old one can be cleared in secure mode.
+func (field *TextField) replaceRunes(start, end int, runes []rune) {
+	buffer := make([]rune, 0, len(field.runes)-(end-start)+len(runes))
+	buffer = append(buffer, field.runes[:start]...)
+	buffer = append(buffer, runes...)
+	buffer = append(buffer, field.runes[end:]...)
+	if field.secure {
+		old := field.runes[:cap(field.runes)]
+		for i := range old {
+			old[i] = 0
+		}
+	}
+	field.runes = buffer
+}
+
+// displayText returns the text as it should appear in the field.
+func (field *TextField) displayText() string {
+	if !field.secure {
+		return string(field.runes)
+	}
+	display := make([]rune, len(field.runes))
+	for i := range display {
+		if i == field.revealIndex {
+			display[i] = field.runes[i]
+		} else {
+			display[i] = field.Theme.SecureRune
+		}
+	}
+	return string(display)
+}
+
+func (field *TextField) reveal(index int) {
+	field.revealIndex = index
+	field.revealUntil = time.Now().Add(field.Theme.RevealDuration)
+	field.Repaint()
+	if window := field.Window(); window.Valid() {
+		window.InvokeAfter(func() {
+			if !time.Now().Before(field.revealUntil) {
+				field.hideRevealed()
+			}
+		}, field.Theme.RevealDuration)
+	}
+}
+
+func (field *TextField) hideRevealed() {
+	if field.revealIndex != -1 {
+		field.revealIndex = -1
+		field.Repaint()
+	}
+}
+
+// SpellChecker returns the spell checker being used, if any.
+func (field *TextField) SpellChecker() spell.Checker {
+	return field.spelling.Checker()
+}
+
+// SetSpellChecker sets the spell checker to use. Pass in nil to disable spell checking. Newly
+// created fields use spell.Default().
+func (field *TextField) SetSpellChecker(checker spell.Checker) {
+	field.spelling.SetChecker(checker)
+	field.spelling.Check(string(field.runes))
+}
+
+func (field *TextField) spellingChecked(misspelled []spell.Range) {
+	field.misspelled = misspelled
+	field.Repaint()
+}
+
+func (field *TextField) misspellingAt(index int) (spell.Range, bool) {
+	for _, word := range field.misspelled {
+		if index >= word.Start && index < word.End && word.End <= len(field.runes) {
+			return word, true
+		}
+	}
+	return spell.Range{}, false
+}
+
+func (field *TextField) showContextMenu(where geom.Point) {
+	index := field.ToSelectionIndex(field.FromWindow(where).X)
+	word, ok := field.misspellingAt(index)
+	if !ok {
+		field.popupContextMenu(where, nil, nil)
+		return
+	}
+	field.SetSelection(word.Start, word.End)
+	checker := field.spelling.Checker()
+	str := string(field.runes[word.Start:word.End])
+	max := field.Theme.MaxSpellingSuggestions
+	// Finding suggestions can take a while, so do it off the UI thread and show the menu once
+	// they're ready, provided the word hasn't been changed in the meantime.
+	go func() {
+		suggestions := checker.Suggestions(str, max)
+		app.Invoke(func() {
+			if wnd := field.Window(); wnd != nil && wnd.Valid() && word.End <= len(field.runes) && string(field.runes[word.Start:word.End]) == str {
+				field.popupContextMenu(where, &word, suggestions)
+			}
+		})
+	}()
+}
+
+// popupContextMenu shows the context menu at 'where'. If 'word' isn't nil, the menu starts with
+// the spelling suggestions for it.
+func (field *TextField) popupContextMenu(where geom.Point, word *spell.Range, suggestions []string) {
+	mnu := menu.NewMenu("")
+	defer mnu.Dispose()
+	if word != nil {
+		field.addSpellingItems(mnu, *word, suggestions)
+		mnu.AppendItem(menu.NewSeparator())
+	}
+	addContextItem(mnu, i18n.Text("Cut"), field.Cut, field.CanCut)
+	addContextItem(mnu, i18n.Text("Copy"), field.Copy, field.CanCopy)
+	addContextItem(mnu, i18n.Text("Paste"), field.Paste, field.CanPaste)
+	mnu.AppendItem(menu.NewSeparator())
+	addContextItem(mnu, i18n.Text("Select All"), field.SelectAll, field.CanSelectAll)
+	mnu.Popup(field.Window().ID(), where, 0, nil)
+}
+
+func (field *TextField) addSpellingItems(mnu menu.Menu, word spell.Range, suggestions []string) {
+	checker := field.spelling.Checker()
+	str := string(field.runes[word.Start:word.End])
+	if len(suggestions) == 0 {
+		addContextItem(mnu, i18n.Text("No Guesses Found"), nil, func() bool { return false })
+	}
+	for _, one := range suggestions {
+		suggestion := one
+		addContextItem(mnu, suggestion, func() { field.replace(word.Start, word.End, suggestion) }, nil)
+	}
+	mnu.AppendItem(menu.NewSeparator())
+	addContextItem(mnu, i18n.Text("Learn Spelling"), func() {
+		// Even if the word couldn't be saved, it has been learned for the rest of this session.
+		spell.Learn(checker, str)
+		field.spelling.Check(string(field.runes))
+	}, nil)
+}
+
+func addContextItem(mnu menu.Menu, title string, action func(), enabled func() bool) {
+	item := menu.NewItem(title, func(evt event.Event) {
+		if action != nil {
+			action()
+		}
+	})
+	if enabled != nil {
+		item.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
+			if !enabled() {
+				evt.(*event.Validate).MarkInvalid()
+			}
+		})
+	}
+	mnu.AppendItem(item)
+}
+
+// replace the runes from 'start' up to, but not including, 'end' with 'str' and select it.
+func (field *TextField) replace(start, end int, str string) {
+	runes := ([]rune)(sanitize(str))
+	field.replaceRunes(start, end, runes)
+	field.SetSelection(start, start+len(runes))
+	field.notifyOfModification()
+}
+
+// CanCut returns true if the field has a selection that can be cut. Secure fields can't be cut.
 func (field *TextField) CanCut() bool {
-	return field.HasSelectionRange()
+	return !field.secure && field.HasSelectionRange()
 }
 
 // Cut the selected text to the clipboard.
 func (field *TextField) Cut() {
-	if field.HasSelectionRange() {
+	if field.CanCut() {
 		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(field.SelectedText())})
 		field.Delete()
 	}
@@ -631,25 +989,27 @@ func (field *TextField) CanDelete() bool {
 func (field *TextField) Delete() {
 	if field.CanDelete() {
 		if field.HasSelectionRange() {
-			field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
+			field.replaceRunes(field.selectionStart, field.selectionEnd, nil)
 			field.SetSelectionTo(field.selectionStart)
 		} else {
-			field.runes = append(field.runes[:field.selectionStart-1], field.runes[field.selectionStart:]...)
-			field.SetSelectionTo(field.selectionStart - 1)
+			prev := field.textLayout().PreviousCursorPosition(field.selectionStart)
+			field.replaceRunes(prev, field.selectionStart, nil)
+			field.SetSelectionTo(prev)
 		}
 		field.notifyOfModification()
 		field.Repaint()
 	}
 }
 
-// CanCopy returns true if the field has a selection that can be copied.
+// CanCopy returns true if the field has a selection that can be copied. Secure fields can't be
+// copied.
 func (field *TextField) CanCopy() bool {
-	return field.HasSelectionRange()
+	return !field.secure && field.HasSelectionRange()
 }
 
 // Copy the selected text to the clipboard.
 func (field *TextField) Copy() {
-	if field.HasSelectionRange() {
+	if field.CanCopy() {
 		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(field.SelectedText())})
 	}
 }
@@ -664,10 +1024,7 @@ func (field *TextField) Paste() {
 	if clipboard.HasType(datatypes.PlainText) {
 		text := sanitize(string(clipboard.Data(datatypes.PlainText)))
 		runes := ([]rune)(text)
-		if field.HasSelectionRange() {
-			field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
-		}
-		field.runes = append(field.runes[:field.selectionStart], append(runes, field.runes[field.selectionStart:]...)...)
+		field.replaceRunes(field.selectionStart, field.selectionEnd, runes)
 		field.SetSelectionTo(field.selectionStart + len(runes))
 		field.notifyOfModification()
 	} else if field.HasSelectionRange() {
@@ -695,3 +1052,9 @@ func (field *TextField) setCursor(evt event.Event) {
 	field.Window().SetCursor(c)
 	evt.Finish()
 }
+
+func (field *TextField) systemFontsChanged(evt event.Event) {
+	field.layout.SetFont(field.Theme.Font)
+	field.autoScroll()
+	field.Repaint()
+}
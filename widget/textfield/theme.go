@@ -23,6 +23,11 @@ type Theme struct {
 	MinimumTextWidth        float64       // The minimum space to permit for text.
 	DisabledBackgroundColor color.Color   // The color to use for the background when disabled.
 	InvalidBackgroundColor  color.Color   // The color to use for the background when marked invalid.
+	MaxSpellingSuggestions  int           // The maximum number of spelling suggestions to offer.
+	SecureRune              rune          // The character displayed in place of each character of a secure field.
+	RevealDuration          time.Duration // How long the last character typed into a secure field is shown.
+	CapsLockWarningColor    color.Color   // The color of the caps lock warning in secure fields.
+	CapsLockWarningSpacing  float64       // The space to leave between the text and the caps lock warning.
 }
 
 // NewTheme creates a new TextField theme.
@@ -41,4 +46,9 @@ func (theme *Theme) Init() {
 	theme.MinimumTextWidth = 10
 	theme.DisabledBackgroundColor = color.Background
 	theme.InvalidBackgroundColor = color.RGB(255, 232, 232)
+	theme.MaxSpellingSuggestions = 5
+	theme.SecureRune = '•'
+	theme.RevealDuration = time.Second
+	theme.CapsLockWarningColor = color.Gray
+	theme.CapsLockWarningSpacing = 4
 }
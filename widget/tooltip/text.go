@@ -5,13 +5,22 @@ import (
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/widget/label"
 )
 
 // SetText sets a text tooltip on the target.
 func SetText(target ui.Widget, text string) {
-	tip := label.New(text)
+	setLabel(target, label.New(text))
+}
+
+// SetAttributedText sets a tooltip showing styled text on the target.
+func SetAttributedText(target ui.Widget, text *draw.AttributedString) {
+	setLabel(target, label.NewAttributed(text))
+}
+
+func setLabel(target ui.Widget, tip *label.Label) {
 	tip.SetBackground(color.LightYellow)
 	tip.SetBorder(border.NewCompound(border.NewLine(color.DarkGray, geom.NewUniformInsets(1)), border.NewEmpty(geom.Insets{Top: 2, Left: 4, Bottom: 2, Right: 4})))
 	target.EventHandlers().Add(event.ToolTipType, func(evt event.Event) { evt.(*Event).SetToolTip(tip) })
@@ -36,6 +36,9 @@ type Window interface {
 	ContentFrame() geom.Rect
 	// SetContentFrame sets the boundaries of the root widget of this window.
 	SetContentFrame(bounds geom.Rect)
+	// Scale returns the number of device pixels per logical unit used when
+	// painting this window.
+	Scale() float64
 	// ContentLocalFrame returns the local boundaries of the root widget of
 	// this window.
 	ContentLocalFrame() geom.Rect
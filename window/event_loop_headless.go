@@ -0,0 +1,194 @@
+//go:build headless
+// +build headless
+
+package window
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox/atexit"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/internal/headless"
+	"github.com/richardwilkes/ui/internal/task"
+	"github.com/richardwilkes/ui/keys"
+)
+
+var (
+	// DoubleClickTime holds the maximum amount of time that can elapse between two clicks for them
+	// to be considered part of a multi-click event.
+	DoubleClickTime time.Duration = time.Millisecond * 250
+	// DoubleClickDistance holds the maximum distance subsequent clicks can be from the last click
+	// when determining if a click is part of a multi-click event.
+	DoubleClickDistance float64 = 5
+)
+
+var (
+	quitting     bool
+	awaitingQuit bool
+)
+
+// RunEventLoop processes tasks and injected events until the application quits.
+func RunEventLoop() {
+	for !quitting {
+		PumpEvents(true)
+	}
+}
+
+// PumpEvents processes all pending tasks and injected events, in the order they were queued, then
+// paints any windows that need it. If 'wait' is true and nothing is pending, blocks until
+// something is. Returns the number of tasks and events processed. Must be called from the UI
+// thread.
+func PumpEvents(wait bool) int {
+	ids := headless.Take(wait)
+	for _, id := range ids {
+		task.Dispatch(id)
+	}
+	for _, window := range windowMap {
+		window.validateAndFlush()
+	}
+	return len(ids)
+}
+
+// PumpEventsFor repeatedly processes pending tasks and injected events until the specified
+// duration has elapsed, which allows timer-based tasks, such as animations and tooltips, to run.
+func PumpEventsFor(duration time.Duration) {
+	deadline := time.Now().Add(duration)
+	for {
+		PumpEvents(false)
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if remaining > time.Millisecond {
+			remaining = time.Millisecond
+		}
+		time.Sleep(remaining)
+	}
+}
+
+func (window *Window) validateAndFlush() {
+	if window.Valid() && !window.minimized {
+		window.root.ValidateLayout()
+		window.platformFlushPainting()
+	}
+}
+
+func (window *Window) inject(handler func()) {
+	headless.Post(task.Record(func() {
+		if window.Valid() {
+			handler()
+		}
+	}))
+}
+
+// InjectMouseDown queues a mouse down event for the window. May be called from any goroutine.
+func (window *Window) InjectMouseDown(where geom.Point, button, clickCount int, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseDown(where.X, where.Y, button, clickCount, keyModifiers) })
+}
+
+// InjectMouseDragged queues a mouse dragged event for the window. May be called from any
+// goroutine.
+func (window *Window) InjectMouseDragged(where geom.Point, button int, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseDragged(where.X, where.Y, button, keyModifiers) })
+}
+
+// InjectMouseUp queues a mouse up event for the window. May be called from any goroutine.
+func (window *Window) InjectMouseUp(where geom.Point, button int, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseUp(where.X, where.Y, button, keyModifiers) })
+}
+
+// InjectMouseEntered queues a mouse entered event for the window. May be called from any
+// goroutine.
+func (window *Window) InjectMouseEntered(where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseEntered(where.X, where.Y, keyModifiers) })
+}
+
+// InjectMouseMoved queues a mouse moved event for the window. May be called from any goroutine.
+func (window *Window) InjectMouseMoved(where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseMoved(where.X, where.Y, keyModifiers) })
+}
+
+// InjectMouseExited queues a mouse exited event for the window. May be called from any
+// goroutine.
+func (window *Window) InjectMouseExited(where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseExited(where.X, where.Y, keyModifiers) })
+}
+
+// InjectMouseWheel queues a mouse wheel event from a notched device for the window. May be called
+// from any goroutine.
+func (window *Window) InjectMouseWheel(where, delta geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processMouseWheel(where.X, where.Y, delta.X, delta.Y, keyModifiers) })
+}
+
+// InjectPreciseMouseWheel queues a mouse wheel event from a precise device, such as a touchpad,
+// for the window. May be called from any goroutine.
+func (window *Window) InjectPreciseMouseWheel(where, delta geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processPreciseMouseWheel(where.X, where.Y, delta.X, delta.Y, keyModifiers) })
+}
+
+// InjectKeyDown queues a key down event for the window. May be called from any goroutine.
+func (window *Window) InjectKeyDown(keyCode int, ch rune, keyModifiers keys.Modifiers, repeat bool) {
+	window.inject(func() { window.processKeyDown(keyCode, ch, keyModifiers, repeat) })
+}
+
+// InjectKeyUp queues a key up event for the window. May be called from any goroutine.
+func (window *Window) InjectKeyUp(keyCode int, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processKeyUp(keyCode, keyModifiers) })
+}
+
+// InjectTouchBegan queues the start of a touch for the window. 'id' identifies the touch in
+// subsequent calls. May be called from any goroutine.
+func (window *Window) InjectTouchBegan(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processTouchBegan(id, where, keyModifiers) })
+}
+
+// InjectTouchMoved queues movement of a touch for the window. May be called from any goroutine.
+func (window *Window) InjectTouchMoved(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processTouchMoved(id, where, keyModifiers) })
+}
+
+// InjectTouchEnded queues the end of a touch for the window. May be called from any goroutine.
+func (window *Window) InjectTouchEnded(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.inject(func() { window.processTouchEnded(id, where, keyModifiers) })
+}
+
+// InjectClose queues a request to close the window, as if the user had clicked its close box.
+// May be called from any goroutine.
+func (window *Window) InjectClose() {
+	window.inject(func() {
+		if window.MayClose() {
+			window.Close()
+		}
+	})
+}
+
+func DeferQuit() {
+	awaitingQuit = true
+}
+
+func StartQuit() {
+	event.SendAppWillQuit()
+	quitting = true
+	if Count() > 0 {
+		for _, w := range Windows() {
+			w.Close()
+		}
+	}
+	finishQuit()
+}
+
+func ResumeQuit(quit bool) {
+	if awaitingQuit {
+		awaitingQuit = false
+		if quit {
+			StartQuit()
+		}
+	}
+}
+
+func finishQuit() {
+	if quitting {
+		atexit.Exit(0)
+	}
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package window
 
 import (
@@ -6,9 +9,12 @@ import (
 
 	"github.com/richardwilkes/toolbox/atexit"
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/font"
 	"github.com/richardwilkes/ui/internal/task"
 	"github.com/richardwilkes/ui/internal/x11"
+	"github.com/richardwilkes/ui/keys"
 )
 
 var (
@@ -34,6 +40,10 @@ var (
 func RunEventLoop() {
 	for x11.Running() {
 		event := x11.NextEvent()
+		if x11.ProcessXSettingsEvent(event) {
+			processDesktopSettingsChange()
+			continue
+		}
 		switch event.Type() {
 		case x11.KeyPressType:
 			processKeyDownEvent(event.ToKeyEvent())
@@ -65,6 +75,8 @@ func RunEventLoop() {
 			x11.ProcessSelectionClearEvent(event.ToSelectionClearEvent())
 		case x11.SelectionRequestType:
 			x11.ProcessSelectionRequestEvent(event.ToSelectionRequestEvent())
+		case x11.GenericEventType:
+			processGenericEvent(event.ToGenericEvent())
 		}
 	}
 }
@@ -90,10 +102,15 @@ func processButtonPressEvent(evt *x11.ButtonEvent) {
 		focusOut(keyWindow)
 	}
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.fromDevice(evt.Where())
 		if evt.IsScrollWheel() {
-			dir := evt.ScrollWheelDirection()
-			window.processMouseWheel(where.X, where.Y, dir.X, dir.Y, evt.Modifiers())
+			// Devices with scroll valuators report the wheel via XInput2 instead, with these button
+			// presses being just emulation for legacy clients. Other devices, such as a plain
+			// notched mouse, still only report it this way.
+			if !evt.IsEmulatedScroll() {
+				dir := evt.ScrollWheelDirection()
+				window.processMouseWheel(where.X, where.Y, dir.X, dir.Y, evt.Modifiers())
+			}
 		} else {
 			lastMouseDownButton = evt.Button()
 			lastMouseDownWindow = wnd
@@ -114,7 +131,7 @@ func processButtonPressEvent(evt *x11.ButtonEvent) {
 func processButtonReleaseEvent(evt *x11.ButtonEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
 		if !evt.IsScrollWheel() {
-			where := evt.Where()
+			where := window.fromDevice(evt.Where())
 			lastMouseDownButton = -1
 			window.processMouseUp(where.X, where.Y, evt.Button(), evt.Modifiers())
 		}
@@ -122,17 +139,24 @@ func processButtonReleaseEvent(evt *x11.ButtonEvent) {
 }
 
 func processMouseEnteredEvent(evt *x11.CrossingEvent) {
+	x11.ResetScrollAxes()
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.fromDevice(evt.Where())
 		window.processMouseEntered(where.X, where.Y, evt.Modifiers())
 	}
 }
 
 func processMotionEvent(evt *x11.MotionEvent) {
-	target := platformWindow(uintptr(evt.Window()))
+	processMotion(platformWindow(uintptr(evt.Window())), evt.Where(), evt.Modifiers())
+}
+
+// processMotion handles mouse movement within the 'target' window. 'where' is in device pixels.
+func processMotion(target platformWindow, where geom.Point, modifiers keys.Modifiers) {
+	if window, ok := windowMap[target]; ok {
+		where = window.fromDevice(where)
+	}
 	if lastMouseDownButton != -1 {
 		if window, ok := windowMap[lastMouseDownWindow]; ok {
-			where := evt.Where()
 			if target != lastMouseDownWindow {
 				if other, ok := windowMap[target]; ok {
 					// Translate the coordinates to the window that had the mouse down
@@ -141,19 +165,62 @@ func processMotionEvent(evt *x11.MotionEvent) {
 					where.Add(bounds.Point)
 				}
 			}
-			window.processMouseDragged(where.X, where.Y, lastMouseDownButton, evt.Modifiers())
+			window.processMouseDragged(where.X, where.Y, lastMouseDownButton, modifiers)
 		}
 	} else {
 		if window, ok := windowMap[target]; ok {
-			where := evt.Where()
-			window.processMouseMoved(where.X, where.Y, evt.Modifiers())
+			window.processMouseMoved(where.X, where.Y, modifiers)
+		}
+	}
+}
+
+func processGenericEvent(evt *x11.GenericEvent) {
+	if evt.Load() {
+		if evt.Process() {
+			switch evt.EvType() {
+			case x11.XIMotionType:
+				processXIMotionEvent(evt.ToDeviceEvent())
+			case x11.XITouchBeginType, x11.XITouchUpdateType, x11.XITouchEndType:
+				processTouchEvent(evt.EvType(), evt.ToDeviceEvent())
+			}
+		}
+		evt.Free()
+	}
+}
+
+func processXIMotionEvent(evt *x11.DeviceEvent) {
+	target := platformWindow(uintptr(evt.Window()))
+	if delta, precise, ok := evt.ScrollDelta(); ok {
+		if window, exists := windowMap[target]; exists {
+			where := window.fromDevice(evt.Where())
+			if precise {
+				window.processPreciseMouseWheel(where.X, where.Y, delta.X, delta.Y, evt.Modifiers())
+			} else {
+				window.processMouseWheel(where.X, where.Y, delta.X, delta.Y, evt.Modifiers())
+			}
+		}
+	} else {
+		processMotion(target, evt.Where(), evt.Modifiers())
+	}
+}
+
+func processTouchEvent(evType int, evt *x11.DeviceEvent) {
+	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
+		where := window.fromDevice(evt.Where())
+		switch evType {
+		case x11.XITouchBeginType:
+			window.processTouchBegan(evt.TouchID(), where, evt.Modifiers())
+		case x11.XITouchUpdateType:
+			window.processTouchMoved(evt.TouchID(), where, evt.Modifiers())
+		case x11.XITouchEndType:
+			window.processTouchEnded(evt.TouchID(), where, evt.Modifiers())
 		}
 	}
 }
 
 func processMouseExitedEvent(evt *x11.CrossingEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.fromDevice(evt.Where())
 		window.processMouseExited(where.X, where.Y, evt.Modifiers())
 	}
 }
@@ -216,7 +283,26 @@ func processConfigureEvent(evt *x11.ConfigureEvent) {
 		size := win.ContentFrame().Size
 		win.root.SetSize(size)
 		win.ignoreRepaint = false
-		win.surface.SetSize(size)
+		win.surface.SetSize(win.deviceContentFrame().Size)
+	}
+}
+
+func processDesktopSettingsChange() {
+	font.ApplyDesktopSettings(x11.DesktopFontSettings())
+	event.SendSystemFontsChanged()
+	// Sizes may have changed along with the fonts, so lay out and redraw everything.
+	for _, window := range windowMap {
+		fontsChanged(window.root)
+		window.root.ValidateLayout()
+		window.Repaint()
+	}
+}
+
+func fontsChanged(widget ui.Widget) {
+	event.Dispatch(event.NewSystemFontsChanged(widget))
+	widget.SetNeedLayout(true)
+	for _, child := range widget.Children() {
+		fontsChanged(child)
 	}
 }
 
@@ -0,0 +1,289 @@
+package window
+
+import (
+	"math"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/event/button"
+	"github.com/richardwilkes/ui/keys"
+)
+
+var (
+	// TouchMouseDelay holds the amount of time a single touch waits for additional touches before
+	// it is treated as the mouse.
+	TouchMouseDelay = 75 * time.Millisecond
+	// TapTime holds the maximum amount of time several touches can remain down and still be
+	// considered a tap.
+	TapTime = 250 * time.Millisecond
+	// DoubleTapTime holds the maximum amount of time that can elapse between two single-finger taps
+	// for them to be considered part of a multi-click event.
+	DoubleTapTime = 300 * time.Millisecond
+	// TapDistance holds the maximum distance a touch can move and still be considered part of a
+	// tap. Also used as the maximum distance between single-finger taps for them to be counted as
+	// a multi-click.
+	TapDistance float64 = 10
+	// PanThreshold holds the distance the center point between two touches must move before a pan
+	// gesture is recognized.
+	PanThreshold float64 = 10
+	// PinchThreshold holds the distance the spacing between two touches must change before a pinch
+	// gesture is recognized.
+	PinchThreshold float64 = 12
+	// RotateThreshold holds the angle, in radians, two touches must rotate before a rotate gesture
+	// is recognized.
+	RotateThreshold = 0.15
+)
+
+const (
+	touchIdle = iota
+	touchPending
+	touchMouse
+	touchGesture
+	touchDraining
+)
+
+type touchPoint struct {
+	id      int
+	start   geom.Point
+	current geom.Point
+}
+
+type gestureRecognizer struct {
+	window        *Window
+	touches       []*touchPoint
+	mode          int
+	sequence      int
+	started       time.Time
+	maxTouches    int
+	moved         bool
+	modifiers     keys.Modifiers
+	target        ui.Widget
+	startCentroid geom.Point
+	startDistance float64
+	startAngle    float64
+	lastCentroid  geom.Point
+	lastScale     float64
+	lastAngle     float64
+	panning       bool
+	pinching      bool
+	rotating      bool
+	lastTap       time.Time
+	lastTapSpot   geom.Point
+	tapCount      int
+}
+
+type touchMouseSequencer struct {
+	recognizer *gestureRecognizer
+	sequence   int
+}
+
+func (tms *touchMouseSequencer) begin() {
+	if tms.recognizer.sequence == tms.sequence && tms.recognizer.mode == touchPending {
+		tms.recognizer.beginMouse()
+	}
+}
+
+func (window *Window) processTouchBegan(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.gestures.window = window
+	window.gestures.touchBegan(id, where, keyModifiers)
+}
+
+func (window *Window) processTouchMoved(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.gestures.window = window
+	window.gestures.touchMoved(id, where, keyModifiers)
+}
+
+func (window *Window) processTouchEnded(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	window.gestures.window = window
+	window.gestures.touchEnded(id, where, keyModifiers)
+}
+
+func (gr *gestureRecognizer) touch(id int) *touchPoint {
+	for _, one := range gr.touches {
+		if one.id == id {
+			return one
+		}
+	}
+	return nil
+}
+
+func (gr *gestureRecognizer) touchBegan(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	gr.modifiers = keyModifiers
+	gr.touches = append(gr.touches, &touchPoint{id: id, start: where, current: where})
+	if len(gr.touches) > gr.maxTouches {
+		gr.maxTouches = len(gr.touches)
+	}
+	switch gr.mode {
+	case touchIdle:
+		gr.mode = touchPending
+		gr.started = time.Now()
+		gr.maxTouches = 1
+		gr.moved = false
+		gr.sequence++
+		tms := &touchMouseSequencer{recognizer: gr, sequence: gr.sequence}
+		gr.window.InvokeAfter(tms.begin, TouchMouseDelay)
+	case touchPending:
+		gr.beginGesture()
+	}
+}
+
+func (gr *gestureRecognizer) touchMoved(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	tp := gr.touch(id)
+	if tp == nil {
+		return
+	}
+	gr.modifiers = keyModifiers
+	tp.current = where
+	if math.Abs(where.X-tp.start.X) > TapDistance || math.Abs(where.Y-tp.start.Y) > TapDistance {
+		gr.moved = true
+	}
+	switch gr.mode {
+	case touchPending:
+		if gr.moved {
+			gr.beginMouse()
+		}
+	case touchMouse:
+		if gr.touches[0] == tp {
+			gr.window.processMouseDragged(where.X, where.Y, button.Left, keyModifiers)
+		}
+	case touchGesture:
+		gr.update(event.GestureChanged)
+	}
+}
+
+func (gr *gestureRecognizer) touchEnded(id int, where geom.Point, keyModifiers keys.Modifiers) {
+	tp := gr.touch(id)
+	if tp == nil {
+		return
+	}
+	gr.modifiers = keyModifiers
+	tp.current = where
+	primary := gr.touches[0] == tp
+	switch gr.mode {
+	case touchPending:
+		gr.beginMouse()
+		fallthrough
+	case touchMouse:
+		if primary {
+			gr.window.processMouseUp(where.X, where.Y, button.Left, keyModifiers)
+		}
+	case touchGesture:
+		if gr.touches[0] == tp || gr.touches[1] == tp {
+			gr.endGesture()
+		}
+	}
+	for i, one := range gr.touches {
+		if one == tp {
+			copy(gr.touches[i:], gr.touches[i+1:])
+			count := len(gr.touches) - 1
+			gr.touches[count] = nil
+			gr.touches = gr.touches[:count]
+			break
+		}
+	}
+	if primary && gr.mode == touchMouse {
+		gr.mode = touchDraining
+	}
+	if len(gr.touches) == 0 {
+		if gr.mode == touchDraining && gr.target != nil && gr.maxTouches > 1 && !gr.moved && time.Since(gr.started) <= TapTime {
+			event.Dispatch(event.NewTap(gr.target, gr.startCentroid, gr.maxTouches, keyModifiers))
+		}
+		gr.mode = touchIdle
+		gr.target = nil
+	}
+}
+
+func (gr *gestureRecognizer) beginMouse() {
+	gr.mode = touchMouse
+	gr.sequence++
+	where := gr.touches[0].start
+	now := time.Now()
+	if now.Sub(gr.lastTap) <= DoubleTapTime && math.Abs(gr.lastTapSpot.X-where.X) <= TapDistance && math.Abs(gr.lastTapSpot.Y-where.Y) <= TapDistance {
+		gr.tapCount++
+	} else {
+		gr.tapCount = 1
+	}
+	gr.lastTap = now
+	gr.lastTapSpot = where
+	gr.window.processMouseDown(where.X, where.Y, button.Left, gr.tapCount, gr.modifiers)
+	if current := gr.touches[0].current; current != where {
+		gr.window.processMouseDragged(current.X, current.Y, button.Left, gr.modifiers)
+	}
+}
+
+func (gr *gestureRecognizer) beginGesture() {
+	gr.mode = touchGesture
+	gr.sequence++
+	gr.startCentroid, gr.startDistance, gr.startAngle = gr.measure()
+	gr.lastCentroid = gr.startCentroid
+	gr.lastScale = 1
+	gr.lastAngle = 0
+	gr.panning = false
+	gr.pinching = false
+	gr.rotating = false
+	gr.target = gr.window.root.WidgetAt(gr.startCentroid)
+}
+
+func (gr *gestureRecognizer) measure() (centroid geom.Point, distance, angle float64) {
+	first := gr.touches[0].current
+	second := gr.touches[1].current
+	centroid = geom.Point{X: (first.X + second.X) / 2, Y: (first.Y + second.Y) / 2}
+	dx := second.X - first.X
+	dy := second.Y - first.Y
+	return centroid, math.Hypot(dx, dy), math.Atan2(dy, dx)
+}
+
+func (gr *gestureRecognizer) update(phase event.GesturePhase) {
+	if gr.target == nil {
+		return
+	}
+	centroid, distance, angle := gr.measure()
+	translation := geom.Point{X: centroid.X - gr.startCentroid.X, Y: centroid.Y - gr.startCentroid.Y}
+	scale := 1.0
+	if gr.startDistance > 0 {
+		scale = distance / gr.startDistance
+	}
+	rotation := normalizeAngle(angle - gr.startAngle)
+	if !gr.panning && phase != event.GestureEnded && math.Hypot(translation.X, translation.Y) > PanThreshold {
+		gr.panning = true
+		event.Dispatch(event.NewPan(gr.target, centroid, translation, translation, event.GestureBegan, gr.modifiers))
+	} else if gr.panning {
+		delta := geom.Point{X: centroid.X - gr.lastCentroid.X, Y: centroid.Y - gr.lastCentroid.Y}
+		event.Dispatch(event.NewPan(gr.target, centroid, translation, delta, phase, gr.modifiers))
+	}
+	if !gr.pinching && phase != event.GestureEnded && math.Abs(distance-gr.startDistance) > PinchThreshold {
+		gr.pinching = true
+		event.Dispatch(event.NewPinch(gr.target, centroid, scale, scale, event.GestureBegan, gr.modifiers))
+	} else if gr.pinching {
+		event.Dispatch(event.NewPinch(gr.target, centroid, scale, scale/gr.lastScale, phase, gr.modifiers))
+	}
+	if !gr.rotating && phase != event.GestureEnded && math.Abs(rotation) > RotateThreshold {
+		gr.rotating = true
+		event.Dispatch(event.NewRotate(gr.target, centroid, rotation, rotation, event.GestureBegan, gr.modifiers))
+	} else if gr.rotating {
+		event.Dispatch(event.NewRotate(gr.target, centroid, rotation, normalizeAngle(rotation-gr.lastAngle), phase, gr.modifiers))
+	}
+	gr.lastCentroid = centroid
+	gr.lastScale = scale
+	gr.lastAngle = rotation
+}
+
+func (gr *gestureRecognizer) endGesture() {
+	if gr.panning || gr.pinching || gr.rotating {
+		gr.moved = true
+		gr.update(event.GestureEnded)
+	}
+	gr.mode = touchDraining
+}
+
+func normalizeAngle(angle float64) float64 {
+	for angle > math.Pi {
+		angle -= 2 * math.Pi
+	}
+	for angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
@@ -0,0 +1,193 @@
+//go:build headless
+// +build headless
+
+package window
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/event"
+)
+
+type touchStep struct {
+	kind  string
+	id    int
+	where geom.Point
+}
+
+type recordedGesture struct {
+	kind  string
+	phase event.GesturePhase
+	value float64
+	where geom.Point
+}
+
+func (r recordedGesture) matches(other recordedGesture) bool {
+	const epsilon = 0.001
+	return r.kind == other.kind && r.phase == other.phase && math.Abs(r.value-other.value) < epsilon && math.Abs(r.where.X-other.where.X) < epsilon && math.Abs(r.where.Y-other.where.Y) < epsilon
+}
+
+func (r recordedGesture) String() string {
+	return fmt.Sprintf("%s %v %.3f (%.2f, %.2f)", r.kind, r.phase, r.value, r.where.X, r.where.Y)
+}
+
+func began(id int, x, y float64) touchStep {
+	return touchStep{kind: "began", id: id, where: geom.Point{X: x, Y: y}}
+}
+
+func moved(id int, x, y float64) touchStep {
+	return touchStep{kind: "moved", id: id, where: geom.Point{X: x, Y: y}}
+}
+
+func ended(id int, x, y float64) touchStep {
+	return touchStep{kind: "ended", id: id, where: geom.Point{X: x, Y: y}}
+}
+
+func TestGestureRecognizer(t *testing.T) {
+	// The touches for the rotation start 30 points either side of (100, 100) and are rotated
+	// about it by 0.4 radians, one at a time.
+	rotatedX := 30 * math.Cos(0.4)
+	rotatedY := 30 * math.Sin(0.4)
+	for _, one := range []struct {
+		name     string
+		steps    []touchStep
+		expected []recordedGesture
+	}{
+		{
+			name: "pinch",
+			steps: []touchStep{
+				began(1, 50, 100),
+				began(2, 150, 100),
+				moved(1, 35, 100),
+				moved(2, 165, 100),
+				ended(1, 35, 100),
+				ended(2, 165, 100),
+			},
+			expected: []recordedGesture{
+				{kind: "pinch", phase: event.GestureBegan, value: 1.15, where: geom.Point{X: 92.5, Y: 100}},
+				{kind: "pinch", phase: event.GestureChanged, value: 1.3, where: geom.Point{X: 100, Y: 100}},
+				{kind: "pinch", phase: event.GestureEnded, value: 1.3, where: geom.Point{X: 100, Y: 100}},
+			},
+		},
+		{
+			name: "rotate",
+			steps: []touchStep{
+				began(1, 70, 100),
+				began(2, 130, 100),
+				moved(1, 100-rotatedX, 100-rotatedY),
+				moved(2, 100+rotatedX, 100+rotatedY),
+				ended(1, 100-rotatedX, 100-rotatedY),
+				ended(2, 100+rotatedX, 100+rotatedY),
+			},
+			expected: []recordedGesture{
+				{kind: "rotate", phase: event.GestureBegan, value: 0.2, where: geom.Point{X: (130 + 100 - rotatedX) / 2, Y: (100 + 100 - rotatedY) / 2}},
+				{kind: "rotate", phase: event.GestureChanged, value: 0.4, where: geom.Point{X: 100, Y: 100}},
+				{kind: "rotate", phase: event.GestureEnded, value: 0.4, where: geom.Point{X: 100, Y: 100}},
+			},
+		},
+		{
+			name: "pan",
+			steps: []touchStep{
+				began(1, 50, 100),
+				began(2, 150, 100),
+				moved(1, 58, 100),
+				moved(2, 158, 100),
+				moved(1, 66, 100),
+				moved(2, 166, 100),
+				ended(1, 66, 100),
+				ended(2, 166, 100),
+			},
+			expected: []recordedGesture{
+				{kind: "pan", phase: event.GestureBegan, value: 12, where: geom.Point{X: 112, Y: 100}},
+				{kind: "pan", phase: event.GestureChanged, value: 16, where: geom.Point{X: 116, Y: 100}},
+				{kind: "pan", phase: event.GestureEnded, value: 16, where: geom.Point{X: 116, Y: 100}},
+			},
+		},
+		{
+			name: "two finger tap",
+			steps: []touchStep{
+				began(1, 50, 100),
+				began(2, 150, 100),
+				ended(1, 50, 100),
+				ended(2, 150, 100),
+			},
+			expected: []recordedGesture{
+				{kind: "tap", value: 2, where: geom.Point{X: 100, Y: 100}},
+			},
+		},
+		{
+			name: "single touch drag",
+			steps: []touchStep{
+				began(1, 50, 50),
+				moved(1, 80, 50),
+				ended(1, 80, 50),
+			},
+			expected: []recordedGesture{
+				{kind: "mouse down", value: 1, where: geom.Point{X: 50, Y: 50}},
+				{kind: "mouse dragged", where: geom.Point{X: 80, Y: 50}},
+				{kind: "mouse up", where: geom.Point{X: 80, Y: 50}},
+			},
+		},
+	} {
+		actual := driveRecognizer(one.steps)
+		if len(actual) != len(one.expected) {
+			t.Errorf("%s: expected %d events, got %d: %v", one.name, len(one.expected), len(actual), actual)
+			continue
+		}
+		for i, expected := range one.expected {
+			if !actual[i].matches(expected) {
+				t.Errorf("%s: event %d: expected %v, got %v", one.name, i, expected, actual[i])
+			}
+		}
+	}
+}
+
+// driveRecognizer feeds the touch steps to a new window's gesture recognizer, returning the
+// gesture and mouse events delivered to the window's content.
+func driveRecognizer(steps []touchStep) []recordedGesture {
+	wnd := NewWindowWithContentSize(geom.Point{}, geom.Size{Width: 200, Height: 200}, BorderlessWindowMask)
+	defer wnd.Close()
+	wnd.root.ValidateLayout()
+	var recorded []recordedGesture
+	handlers := wnd.Content().EventHandlers()
+	handlers.Add(event.PinchType, func(evt event.Event) {
+		e := evt.(*event.Pinch)
+		recorded = append(recorded, recordedGesture{kind: "pinch", phase: e.Phase(), value: e.Scale(), where: e.Where()})
+	})
+	handlers.Add(event.RotateType, func(evt event.Event) {
+		e := evt.(*event.Rotate)
+		recorded = append(recorded, recordedGesture{kind: "rotate", phase: e.Phase(), value: e.Angle(), where: e.Where()})
+	})
+	handlers.Add(event.PanType, func(evt event.Event) {
+		e := evt.(*event.Pan)
+		recorded = append(recorded, recordedGesture{kind: "pan", phase: e.Phase(), value: e.Translation().X, where: e.Where()})
+	})
+	handlers.Add(event.TapType, func(evt event.Event) {
+		e := evt.(*event.Tap)
+		recorded = append(recorded, recordedGesture{kind: "tap", value: float64(e.Touches()), where: e.Where()})
+	})
+	handlers.Add(event.MouseDownType, func(evt event.Event) {
+		e := evt.(*event.MouseDown)
+		recorded = append(recorded, recordedGesture{kind: "mouse down", value: float64(e.Clicks()), where: e.Where()})
+	})
+	handlers.Add(event.MouseDraggedType, func(evt event.Event) {
+		recorded = append(recorded, recordedGesture{kind: "mouse dragged", where: evt.(*event.MouseDragged).Where()})
+	})
+	handlers.Add(event.MouseUpType, func(evt event.Event) {
+		recorded = append(recorded, recordedGesture{kind: "mouse up", where: evt.(*event.MouseUp).Where()})
+	})
+	for _, step := range steps {
+		switch step.kind {
+		case "began":
+			wnd.processTouchBegan(step.id, step.where, 0)
+		case "moved":
+			wnd.processTouchMoved(step.id, step.where, 0)
+		case "ended":
+			wnd.processTouchEnded(step.id, step.where, 0)
+		}
+	}
+	return recorded
+}
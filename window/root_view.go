@@ -14,9 +14,10 @@ import (
 // RootView provides a root view for a window.
 type RootView struct {
 	widget.Block
-	tooltip ui.Widget
-	menuBar menu.Bar
-	content ui.Widget
+	tooltip      ui.Widget
+	menuBar      menu.Bar
+	accessoryBar ui.Widget
+	content      ui.Widget
 }
 
 func newRootView(window ui.Window) *RootView {
@@ -48,6 +49,23 @@ func (view *RootView) SetMenuBar(bar menu.Bar) {
 	}
 }
 
+// AccessoryBar returns the accessory bar, or nil.
+func (view *RootView) AccessoryBar() ui.Widget {
+	return view.accessoryBar
+}
+
+// SetAccessoryBar sets the accessory bar, which is placed between the menu bar and the content.
+// Pass nil to remove it.
+func (view *RootView) SetAccessoryBar(bar ui.Widget) {
+	if view.accessoryBar != nil {
+		view.RemoveChild(view.accessoryBar)
+	}
+	view.accessoryBar = bar
+	if bar != nil {
+		view.AddChildAtIndex(bar, view.IndexOfChild(view.content))
+	}
+}
+
 // Tooltip returns the tooltip for this component.
 func (view *RootView) Tooltip() ui.Widget {
 	return view.tooltip
@@ -87,6 +105,12 @@ func (lay *RootLayout) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 			lay.adjustSizeForBarSize(&max, barSize)
 		}
 	}
+	if lay.view.accessoryBar != nil {
+		_, barSize, _ := ui.Sizes(lay.view.accessoryBar, layout.NoHintSize)
+		lay.adjustSizeForBarSize(&min, barSize)
+		lay.adjustSizeForBarSize(&pref, barSize)
+		lay.adjustSizeForBarSize(&max, barSize)
+	}
 	return
 }
 
@@ -108,5 +132,11 @@ func (lay *RootLayout) Layout() {
 			bounds.Height -= size.Height
 		}
 	}
+	if lay.view.accessoryBar != nil {
+		_, size, _ := ui.Sizes(lay.view.accessoryBar, layout.NoHintSize)
+		lay.view.accessoryBar.SetBounds(geom.Rect{Point: bounds.Point, Size: geom.Size{Width: bounds.Width, Height: size.Height}})
+		bounds.Y += size.Height
+		bounds.Height -= size.Height
+	}
 	lay.view.content.SetBounds(bounds)
 }
@@ -0,0 +1,23 @@
+package window
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+func scalePoint(pt geom.Point, scale float64) geom.Point {
+	return geom.Point{X: pt.X * scale, Y: pt.Y * scale}
+}
+
+func scaleRect(bounds geom.Rect, scale float64) geom.Rect {
+	return geom.Rect{Point: scalePoint(bounds.Point, scale), Size: geom.Size{Width: bounds.Width * scale, Height: bounds.Height * scale}}
+}
+
+// scaleRectOut scales the rectangle, then expands it as needed to align with whole units, so that
+// it covers every unit the original touched.
+func scaleRectOut(bounds geom.Rect, scale float64) geom.Rect {
+	x := math.Floor(bounds.X * scale)
+	y := math.Floor(bounds.Y * scale)
+	return geom.Rect{Point: geom.Point{X: x, Y: y}, Size: geom.Size{Width: math.Ceil((bounds.X+bounds.Width)*scale) - x, Height: math.Ceil((bounds.Y+bounds.Height)*scale) - y}}
+}
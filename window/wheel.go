@@ -0,0 +1,55 @@
+package window
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+)
+
+var (
+	// PreciseWheelEndDelay holds the amount of time that must elapse without further input from a
+	// precise scrolling device, such as a touchpad, before the scroll gesture is considered to have
+	// ended.
+	PreciseWheelEndDelay = 100 * time.Millisecond
+)
+
+type wheelSequencer struct {
+	window    *Window
+	where     geom.Point
+	modifiers keys.Modifiers
+	sequence  int
+}
+
+func (ws *wheelSequencer) end() {
+	if ws.window.wheelSequence == ws.sequence && ws.window.wheelWidget != nil {
+		widget := ws.window.wheelWidget
+		ws.window.wheelWidget = nil
+		event.Dispatch(event.NewPreciseMouseWheel(widget, geom.Point{}, ws.where, ws.modifiers, event.WheelPhaseEnded))
+	}
+}
+
+func (window *Window) processPreciseMouseWheel(x, y, dx, dy float64, keyModifiers keys.Modifiers) {
+	where := geom.Point{X: x, Y: y}
+	phase := event.WheelPhaseChanged
+	if window.wheelWidget == nil {
+		window.wheelWidget = window.root.WidgetAt(where)
+		phase = event.WheelPhaseBegan
+	}
+	if window.wheelWidget != nil {
+		event.Dispatch(event.NewPreciseMouseWheel(window.wheelWidget, geom.Point{X: dx, Y: dy}, where, keyModifiers, phase))
+		window.wheelSequence++
+		ws := &wheelSequencer{window: window, where: where, modifiers: keyModifiers, sequence: window.wheelSequence}
+		window.InvokeAfter(ws.end, PreciseWheelEndDelay)
+		window.processMouseAfterWheel(x, y, keyModifiers)
+	}
+}
+
+func (window *Window) processMouseAfterWheel(x, y float64, keyModifiers keys.Modifiers) {
+	if window.inMouseDown {
+		window.processMouseDragged(x, y, 0, keyModifiers)
+	} else {
+		window.processMouseMoved(x, y, keyModifiers)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/richardwilkes/ui/layout"
 	"github.com/richardwilkes/ui/menu"
 	"github.com/richardwilkes/ui/object"
+	"github.com/richardwilkes/ui/widget"
 	"github.com/richardwilkes/ui/widget/tooltip"
 )
 
@@ -46,6 +47,10 @@ type commonWindow struct {
 	initialLocationRequest geom.Point
 	tooltipWidget          ui.Widget
 	tooltipSequence        int
+	wheelWidget            ui.Widget
+	wheelSequence          int
+	gestures               gestureRecognizer
+	scale                  float64
 	inMouseDown            bool
 	ignoreRepaint          bool
 }
@@ -117,6 +122,9 @@ func NewPopupWindow(parent ui.Window, where geom.Point, contentSize geom.Size) *
 
 func newWindow(window *Window, styleMask StyleMask, where geom.Point) *Window {
 	window.style = styleMask
+	if window.scale <= 0 {
+		window.scale = 1
+	}
 	window.InitTypeAndID(window)
 	windowMap[window.window] = window
 	windowIDMap[window.ID()] = window
@@ -167,11 +175,13 @@ func (window *Window) AttemptClose() {
 
 // Close the window.
 func (window *Window) Close() {
+	widget.ReleaseLayers(window.root)
 	window.platformClose()
 }
 
 // Dispose of the window.
 func (window *Window) Dispose() {
+	widget.ReleaseLayers(window.root)
 	event.Dispatch(event.NewClosed(window))
 	delete(windowIDMap, window.ID())
 	delete(windowMap, window.window)
@@ -236,6 +246,12 @@ func (window *Window) ContentFrame() geom.Rect {
 	return window.platformContentFrame()
 }
 
+// Scale returns the number of device pixels per logical unit used when painting this window.
+// Widgets always work in logical units; the scale is applied to the Cairo transform.
+func (window *Window) Scale() float64 {
+	return window.scale
+}
+
 // SetContentFrame sets the boundaries of the root widget of this window.
 func (window *Window) SetContentFrame(bounds geom.Rect) {
 	frame := window.Frame()
@@ -279,6 +295,21 @@ func (window *Window) Content() ui.Widget {
 	return window.root.Content()
 }
 
+// AccessoryBar returns the accessory bar of the window, or nil.
+func (window *Window) AccessoryBar() ui.Widget {
+	return window.root.AccessoryBar()
+}
+
+// SetAccessoryBar sets a widget, such as a find bar, to be shown across the top of the window,
+// between the menu bar and the content. Pass nil to remove it. The content is resized to make room
+// for it.
+func (window *Window) SetAccessoryBar(bar ui.Widget) {
+	window.root.SetAccessoryBar(bar)
+	window.root.SetNeedLayout(true)
+	window.root.ValidateLayout()
+	window.Repaint()
+}
+
 // Focused returns true if the window has the current keyboard focus.
 func (window *Window) Focused() bool {
 	return window == KeyWindow()
@@ -311,7 +342,7 @@ func (window *Window) FocusNext() {
 	if current == nil {
 		current = window.root.Content()
 	}
-	i, focusables := collectFocusables(window.root.Content(), current, make([]ui.Widget, 0))
+	i, focusables := window.collectFocusables(current)
 	size := len(focusables)
 	if size > 0 {
 		i++
@@ -321,6 +352,9 @@ func (window *Window) FocusNext() {
 		current = focusables[i]
 	}
 	window.SetFocus(current)
+	if current != nil {
+		current.ScrollIntoView()
+	}
 }
 
 // FocusPrevious moves the keyboard focus to the previous focusable widget.
@@ -329,7 +363,7 @@ func (window *Window) FocusPrevious() {
 	if current == nil {
 		current = window.root.Content()
 	}
-	i, focusables := collectFocusables(window.root.Content(), current, make([]ui.Widget, 0))
+	i, focusables := window.collectFocusables(current)
 	size := len(focusables)
 	if size > 0 {
 		i--
@@ -339,6 +373,24 @@ func (window *Window) FocusPrevious() {
 		current = focusables[i]
 	}
 	window.SetFocus(current)
+	if current != nil {
+		current.ScrollIntoView()
+	}
+}
+
+// collectFocusables returns the focusable widgets within the accessory bar and the content, along
+// with the index of 'target' within them, or -1.
+func (window *Window) collectFocusables(target ui.Widget) (int, []ui.Widget) {
+	match := -1
+	focusables := make([]ui.Widget, 0)
+	if bar := window.root.AccessoryBar(); bar != nil {
+		match, focusables = collectFocusables(bar, target, focusables)
+	}
+	m, focusables := collectFocusables(window.root.Content(), target, focusables)
+	if match == -1 {
+		match = m
+	}
+	return match, focusables
 }
 
 func collectFocusables(current ui.Widget, target ui.Widget, focusables []ui.Widget) (int, []ui.Widget) {
@@ -453,6 +505,13 @@ func (window *Window) SetCursor(cur *cursor.Cursor) {
 	}
 }
 
+// CurrentModifiers returns the modifier keys currently held down, along with the state of caps lock.
+// This is useful when there is no input event at hand to supply them, such as when a widget gains
+// the keyboard focus.
+func CurrentModifiers() keys.Modifiers {
+	return platformCurrentModifiers()
+}
+
 // HideCursorUntilMouseMoves causes the cursor to disappear until it is moved.
 func HideCursorUntilMouseMoves() {
 	platformHideCursorUntilMouseMoves()
@@ -566,11 +625,7 @@ func (window *Window) processMouseWheel(x, y, dx, dy float64, keyModifiers keys.
 	widget := window.root.WidgetAt(where)
 	if widget != nil {
 		event.Dispatch(event.NewMouseWheel(widget, geom.Point{X: dx, Y: dy}, where, keyModifiers))
-		if window.inMouseDown {
-			window.processMouseDragged(x, y, 0, keyModifiers)
-		} else {
-			window.processMouseMoved(x, y, keyModifiers)
-		}
+		window.processMouseAfterWheel(x, y, keyModifiers)
 	}
 }
 
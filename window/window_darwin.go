@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package window
 
 import (
@@ -35,6 +38,10 @@ func platformHideCursorUntilMouseMoves() {
 	C.hideCursorUntilMouseMoves()
 }
 
+func platformCurrentModifiers() keys.Modifiers {
+	return keys.Modifiers(C.currentModifiers())
+}
+
 func platformNewWindow(bounds geom.Rect, styleMask StyleMask) *Window {
 	return &Window{
 		window: platformWindow(C.newWindow(C.double(bounds.X), C.double(bounds.Y), C.double(bounds.Width), C.double(bounds.Height), C.int(styleMask))),
@@ -199,6 +206,13 @@ func handleWindowMouseWheelEvent(cWindow platformWindow, x, y, dx, dy float64, k
 	}
 }
 
+//export handleWindowPreciseMouseWheelEvent
+func handleWindowPreciseMouseWheelEvent(cWindow platformWindow, x, y, dx, dy float64, keyModifiers int) {
+	if window, ok := windowMap[cWindow]; ok && (dx != 0 || dy != 0) {
+		window.processPreciseMouseWheel(x, y, dx, dy, keys.Modifiers(keyModifiers))
+	}
+}
+
 //export handleCursorUpdateEvent
 func handleCursorUpdateEvent(cWindow platformWindow, x, y float64, keyModifiers int) {
 	if window, ok := windowMap[cWindow]; ok {
@@ -10,6 +10,7 @@ typedef void *platformWindow;
 platformWindow getKeyWindow();
 void bringAllWindowsToFront();
 void hideCursorUntilMouseMoves();
+int currentModifiers();
 void closeWindow(platformWindow window);
 const char *getWindowTitle(platformWindow window);
 void setWindowTitle(platformWindow window, const char *title);
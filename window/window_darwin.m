@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 #include "window_darwin.h"
 #include "_cgo_export.h"
 
@@ -53,7 +56,12 @@
 
 -(void)scrollWheel:(NSEvent *)theEvent {
 	NSPoint where = [self convertPoint:theEvent.locationInWindow fromView:nil];
-	handleWindowMouseWheelEvent((platformWindow)[self window], where.x, where.y, theEvent.scrollingDeltaX, theEvent.scrollingDeltaY, [self getModifiers:theEvent]);
+	if (theEvent.hasPreciseScrollingDeltas) {
+		// Precise deltas are reported in points, so convert them to roughly the same units a notched wheel produces
+		handleWindowPreciseMouseWheelEvent((platformWindow)[self window], where.x, where.y, theEvent.scrollingDeltaX / 10, theEvent.scrollingDeltaY / 10, [self getModifiers:theEvent]);
+	} else {
+		handleWindowMouseWheelEvent((platformWindow)[self window], where.x, where.y, theEvent.scrollingDeltaX, theEvent.scrollingDeltaY, [self getModifiers:theEvent]);
+	}
 }
 
 -(void)flagsChanged:(NSEvent *)theEvent {
@@ -190,6 +198,11 @@ void hideCursorUntilMouseMoves() {
 	[NSCursor setHiddenUntilMouseMoves:YES];
 }
 
+int currentModifiers() {
+	// macOS uses the same modifier mask bit order as we do, but it is shifted up by 16 bits
+	return ([NSEvent modifierFlags] & (NSEventModifierFlagCapsLock | NSEventModifierFlagShift | NSEventModifierFlagControl | NSEventModifierFlagOption | NSEventModifierFlagCommand)) >> 16;
+}
+
 void closeWindow(platformWindow window) {
 	[((NSWindow *)window) close];
 }
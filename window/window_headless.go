@@ -0,0 +1,192 @@
+//go:build headless
+// +build headless
+
+package window
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/display"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/internal/headless"
+	"github.com/richardwilkes/ui/keys"
+)
+
+// Window represents a window on the display.
+type Window struct {
+	commonWindow
+	surface   *draw.Surface
+	title     string
+	frame     geom.Rect
+	dirty     geom.Rect
+	minimized bool
+}
+
+type platformWindow uintptr
+
+var (
+	nextPlatformWindow platformWindow = 1
+	keyWindow          platformWindow
+)
+
+func platformGetKeyWindow() platformWindow {
+	return keyWindow
+}
+
+func platformBringAllWindowsToFront() {
+	list := Windows()
+	for i := len(list) - 1; i >= 0; i-- {
+		list[i].ToFront()
+	}
+}
+
+func platformHideCursorUntilMouseMoves() {
+	// Not applicable
+}
+
+func platformCurrentModifiers() keys.Modifiers {
+	return 0
+}
+
+func platformNewWindow(bounds geom.Rect, styleMask StyleMask) *Window {
+	wnd := nextPlatformWindow
+	nextPlatformWindow++
+	scale := display.Scale()
+	return &Window{
+		commonWindow: commonWindow{window: wnd, scale: scale},
+		surface:      draw.NewImageSurface(scaleRectOut(geom.Rect{Size: bounds.Size}, scale).Size),
+		frame:        bounds,
+	}
+}
+
+func platformNewPopupWindow(parent ui.Window, bounds geom.Rect) *Window {
+	return platformNewWindow(bounds, BorderlessWindowMask)
+}
+
+func (window *Window) platformClose() {
+	if keyWindow == window.window {
+		keyWindow = 0
+	}
+	window.surface.Destroy()
+	window.Dispose()
+}
+
+func (window *Window) platformTitle() string {
+	return window.title
+}
+
+func (window *Window) platformSetTitle(title string) {
+	window.title = title
+}
+
+func (window *Window) platformFrame() geom.Rect {
+	return window.frame
+}
+
+func (window *Window) platformSetFrame(bounds geom.Rect) {
+	bounds.Width = float64(int(bounds.Width))
+	bounds.Height = float64(int(bounds.Height))
+	resized := bounds.Size != window.frame.Size
+	window.frame = bounds
+	if resized {
+		window.surface.Destroy()
+		window.surface = draw.NewImageSurface(scaleRectOut(geom.Rect{Size: bounds.Size}, window.scale).Size)
+		window.ignoreRepaint = true
+		window.root.SetSize(bounds.Size)
+		window.ignoreRepaint = false
+		window.Repaint()
+	}
+}
+
+func (window *Window) platformContentFrame() geom.Rect {
+	return window.frame
+}
+
+func (window *Window) platformToFront() {
+	window.minimized = false
+	if keyWindow != window.window {
+		if previous, ok := windowMap[keyWindow]; ok {
+			event.Dispatch(event.NewFocusLost(previous))
+		} else {
+			event.SendAppWillActivate()
+			event.SendAppDidActivate()
+		}
+		keyWindow = window.window
+		event.Dispatch(event.NewFocusGained(window))
+	}
+	window.Repaint()
+}
+
+func (window *Window) platformRepaint(bounds geom.Rect) {
+	if window.dirty.IsEmpty() {
+		window.dirty = bounds
+	} else {
+		window.dirty.Union(bounds)
+	}
+}
+
+func (window *Window) draw(bounds geom.Rect) {
+	deviceBounds := scaleRectOut(bounds, window.scale)
+	buffer := window.surface.CreateSimilar(draw.ColorContent, window.surface.Size())
+	gc := draw.NewGraphics(buffer.NewCairoContext())
+	gc.Rect(deviceBounds)
+	gc.Clip()
+	gc.Scale(window.scale, window.scale)
+	window.paint(gc, bounds)
+	gc.Dispose()
+
+	gc = draw.NewGraphics(window.surface.NewCairoContext())
+	gc.Rect(deviceBounds)
+	gc.Clip()
+	gc.SetSurface(buffer, 0, 0)
+	gc.FillClip()
+	gc.Dispose()
+
+	buffer.Destroy()
+}
+
+func (window *Window) platformFlushPainting() {
+	if !window.dirty.IsEmpty() {
+		bounds := window.dirty
+		window.dirty = geom.Rect{}
+		window.draw(bounds)
+	}
+}
+
+func (window *Window) platformMinimize() {
+	window.minimized = true
+	if keyWindow == window.window {
+		keyWindow = 0
+		event.Dispatch(event.NewFocusLost(window))
+	}
+}
+
+func (window *Window) platformZoom() {
+	window.platformSetFrame(display.MainBounds())
+}
+
+func (window *Window) platformSetCursor(c *cursor.Cursor) {
+	// Not applicable
+}
+
+func (window *Window) platformInvoke(id uint64) {
+	headless.Post(id)
+}
+
+func (window *Window) platformInvokeAfter(id uint64, after time.Duration) {
+	time.AfterFunc(after, func() {
+		headless.Post(id)
+	})
+}
+
+// Snapshot paints any areas that need it and then returns a copy of the window's content, in device
+// pixels.
+func (window *Window) Snapshot() *draw.ImageData {
+	window.root.ValidateLayout()
+	window.platformFlushPainting()
+	return window.surface.ImageData()
+}
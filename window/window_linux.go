@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package window
 
 import (
@@ -7,8 +10,10 @@ import (
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/display"
 	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/internal/x11"
+	"github.com/richardwilkes/ui/keys"
 )
 
 // Window represents a window on the display.
@@ -44,18 +49,26 @@ func platformHideCursorUntilMouseMoves() {
 	}
 }
 
+func platformCurrentModifiers() keys.Modifiers {
+	return x11.CurrentModifiers()
+}
+
 func platformNewWindow(bounds geom.Rect, styleMask StyleMask) *Window {
+	scale := display.Scale()
+	bounds = scaleRectOut(bounds, scale)
 	wnd := x11.NewWindow(bounds)
 	return &Window{
-		commonWindow: commonWindow{window: platformWindow(uintptr(wnd))},
+		commonWindow: commonWindow{window: platformWindow(uintptr(wnd)), scale: scale},
 		surface:      wnd.NewSurface(bounds.Size),
 	}
 }
 
 func platformNewPopupWindow(parent ui.Window, bounds geom.Rect) *Window {
+	scale := parent.Scale()
+	bounds = scaleRectOut(bounds, scale)
 	wnd := x11.NewPopupWindow(x11.Window(uintptr(parent.PlatformPtr())), bounds)
 	return &Window{
-		commonWindow: commonWindow{window: platformWindow(uintptr(wnd))},
+		commonWindow: commonWindow{window: platformWindow(uintptr(wnd)), scale: scale},
 		surface:      wnd.NewSurface(bounds.Size),
 	}
 }
@@ -86,26 +99,34 @@ func (window *Window) frameDecorationSpace() (top, left, bottom, right float64)
 }
 
 func (window *Window) platformFrame() geom.Rect {
-	bounds := window.platformContentFrame()
+	bounds := window.deviceContentFrame()
 	top, left, bottom, right := window.frameDecorationSpace()
 	bounds.X -= left
 	bounds.Y -= top
 	bounds.Width += left + right
 	bounds.Height += top + bottom
-	return bounds
+	return scaleRect(bounds, 1/window.scale)
 }
 
 func (window *Window) platformSetFrame(bounds geom.Rect) {
-	window.toXWindow().SetFrame(bounds)
+	window.toXWindow().SetFrame(scaleRectOut(bounds, window.scale))
 }
 
 func (window *Window) platformContentFrame() geom.Rect {
+	return scaleRect(window.deviceContentFrame(), 1/window.scale)
+}
+
+func (window *Window) deviceContentFrame() geom.Rect {
 	if window.Valid() {
 		return window.toXWindow().ContentFrame()
 	}
 	return geom.Rect{}
 }
 
+func (window *Window) fromDevice(where geom.Point) geom.Point {
+	return scalePoint(where, 1/window.scale)
+}
+
 func (window *Window) platformToFront() {
 	wnd := window.toXWindow()
 	if window.wasMapped {
@@ -116,7 +137,7 @@ func (window *Window) platformToFront() {
 		for {
 			if event := wnd.NextEventOfType(x11.MapNotifyType); event != nil {
 				window.wasMapped = true
-				wnd.Move(window.initialLocationRequest)
+				wnd.Move(scalePoint(window.initialLocationRequest, window.scale))
 				if window.owner == nil {
 					// Wait for window to be configured so that we have correct placement information
 					for {
@@ -137,15 +158,17 @@ func (window *Window) platformToFront() {
 }
 
 func (window *Window) platformRepaint(bounds geom.Rect) {
-	window.toXWindow().Repaint(bounds)
+	window.toXWindow().Repaint(scaleRectOut(bounds, window.scale))
 }
 
+// draw paints the area 'bounds', which is in device pixels.
 func (window *Window) draw(bounds geom.Rect) {
 	buffer := window.surface.CreateSimilar(draw.ColorContent, window.surface.Size())
 	gc := draw.NewGraphics(buffer.NewCairoContext())
 	gc.Rect(bounds)
 	gc.Clip()
-	window.paint(gc, bounds)
+	gc.Scale(window.scale, window.scale)
+	window.paint(gc, scaleRectOut(bounds, 1/window.scale))
 	gc.Dispose()
 
 	gc = draw.NewGraphics(window.surface.NewCairoContext())
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package window
 
 import (
@@ -7,6 +10,7 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/keys"
 )
 
 // Window represents a window on the display.
@@ -27,6 +31,11 @@ func platformHideCursorUntilMouseMoves() {
 	// RAW: Implement for Windows
 }
 
+func platformCurrentModifiers() keys.Modifiers {
+	// RAW: Implement for Windows
+	return 0
+}
+
 func platformNewWindow(bounds geom.Rect, styleMask StyleMask) *Window {
 	// RAW: Implement for Windows
 	return nil